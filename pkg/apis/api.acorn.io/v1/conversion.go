@@ -58,3 +58,18 @@ func convert_url_Values_To__LogOptions(in *url.Values, out *LogOptions, s conver
 func Convert_url_Values_To__LogOptions(in, out interface{}, s conversion.Scope) error {
 	return convert_url_Values_To__LogOptions(in.(*url.Values), out.(*LogOptions), s)
 }
+
+func convert_url_Values_To__VolumeArchiveOptions(in *url.Values, out *VolumeArchiveOptions, s conversion.Scope) error {
+	if values, ok := map[string][]string(*in)["subPath"]; ok && len(values) > 0 {
+		if err := runtime.Convert_Slice_string_To_string(&values, &out.SubPath, s); err != nil {
+			return err
+		}
+	} else {
+		out.SubPath = ""
+	}
+	return nil
+}
+
+func Convert_url_Values_To__VolumeArchiveOptions(in, out interface{}, s conversion.Scope) error {
+	return convert_url_Values_To__VolumeArchiveOptions(in.(*url.Values), out.(*VolumeArchiveOptions), s)
+}
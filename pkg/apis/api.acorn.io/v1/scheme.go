@@ -29,6 +29,8 @@ func AddToSchemeWithGV(scheme *runtime.Scheme, schemeGroupVersion schema.GroupVe
 		&BuilderList{},
 		&ConfirmUpgrade{},
 		&AppPullImage{},
+		&AppHistory{},
+		&AppDiff{},
 		&Image{},
 		&ImageList{},
 		&ImageDetails{},
@@ -37,9 +39,15 @@ func AddToSchemeWithGV(scheme *runtime.Scheme, schemeGroupVersion schema.GroupVe
 		&ImagePull{},
 		&Info{},
 		&InfoList{},
+		&EncryptionKeyRotation{},
+		&EncryptionKeyDeletion{},
 		&LogOptions{},
 		&Volume{},
 		&VolumeList{},
+		&VolumeSnapshot{},
+		&VolumeSnapshotList{},
+		&VolumeSnapshotRestore{},
+		&VolumeArchiveOptions{},
 		&Credential{},
 		&CredentialList{},
 		&ContainerReplica{},
@@ -63,6 +71,9 @@ func AddToSchemeWithGV(scheme *runtime.Scheme, schemeGroupVersion schema.GroupVe
 		if err := scheme.AddConversionFunc((*url.Values)(nil), (*ContainerReplicaExecOptions)(nil), Convert_url_Values_To__ContainerReplicaExecOptions); err != nil {
 			return err
 		}
+		if err := scheme.AddConversionFunc((*url.Values)(nil), (*VolumeArchiveOptions)(nil), Convert_url_Values_To__VolumeArchiveOptions); err != nil {
+			return err
+		}
 		return scheme.AddConversionFunc((*url.Values)(nil), (*LogOptions)(nil), Convert_url_Values_To__LogOptions)
 	}
 
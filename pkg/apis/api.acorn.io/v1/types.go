@@ -146,6 +146,54 @@ type ConfirmUpgrade struct {
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
+type AppHistory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Revisions are sorted from oldest to newest, the last revision is the one currently deployed
+	Revisions []AppRevision `json:"revisions,omitempty"`
+}
+
+type AppRevision struct {
+	Revision int64              `json:"revision,omitempty"`
+	Created  metav1.Time        `json:"created,omitempty"`
+	Spec     v1.AppInstanceSpec `json:"spec,omitempty"`
+	AppImage v1.AppImage        `json:"appImage,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type AppDiff struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// Input Params
+	Spec v1.AppInstanceSpec `json:"spec,omitempty"`
+
+	// Output Params
+	Image   string          `json:"image,omitempty"`
+	Digest  string          `json:"digest,omitempty"`
+	Changes []AppSpecChange `json:"changes,omitempty"`
+}
+
+type AppSpecChangeAction string
+
+const (
+	AppSpecChangeAdded   = AppSpecChangeAction("added")
+	AppSpecChangeRemoved = AppSpecChangeAction("removed")
+	AppSpecChangeChanged = AppSpecChangeAction("changed")
+)
+
+type AppSpecChange struct {
+	Action AppSpecChangeAction `json:"action,omitempty"`
+	Kind   string              `json:"kind,omitempty"`
+	Path   string              `json:"path,omitempty"`
+	Old    string              `json:"old,omitempty"`
+	New    string              `json:"new,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
 type ImageDetails struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
@@ -213,6 +261,7 @@ type VolumeStatus struct {
 	AppNamespace string        `json:"appNamespace,omitempty"`
 	VolumeName   string        `json:"volumeName,omitempty"`
 	Status       string        `json:"status,omitempty"`
+	Resize       string        `json:"resize,omitempty"`
 	Columns      VolumeColumns `json:"columns,omitempty"`
 }
 
@@ -220,6 +269,58 @@ type VolumeColumns struct {
 	AccessModes string `json:"accessModes,omitempty"`
 }
 
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VolumeSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec   VolumeSnapshotSpec   `json:"spec,omitempty"`
+	Status VolumeSnapshotStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VolumeSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumeSnapshot `json:"items"`
+}
+
+type VolumeSnapshotSpec struct {
+	// Volume is the name of the volume the snapshot is taken of
+	Volume string `json:"volume,omitempty"`
+}
+
+type VolumeSnapshotStatus struct {
+	AppName           string             `json:"appName,omitempty"`
+	VolumeName        string             `json:"volumeName,omitempty"`
+	SnapshotNamespace string             `json:"snapshotNamespace,omitempty"`
+	ReadyToUse        bool               `json:"readyToUse,omitempty"`
+	RestoreSize       *resource.Quantity `json:"restoreSize,omitempty"`
+	Status            string             `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VolumeSnapshotRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// VolumeName is the name the restored volume can be bound with
+	VolumeName string `json:"volumeName,omitempty"`
+}
+
+// +k8s:conversion-gen:explicit-from=net/url.Values
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VolumeArchiveOptions struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// SubPath is the directory of the volume to export or import to, the root of the volume if not set
+	SubPath string `json:"subPath,omitempty"`
+}
+
 // +k8s:conversion-gen:explicit-from=net/url.Values
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -324,11 +425,44 @@ type Config struct {
 	PublishBuilders              *bool          `json:"publishBuilders" name:"publish-builders" usage:"Publish the builders through ingress to so build traffic does not traverse the api-server"`
 	BuilderPerNamespace          *bool          `json:"builderPerNamespace" name:"builder-per-namespace" usage:"Create a dedicated builder per namespace"`
 	InternalRegistryPrefix       string         `json:"internalRegistryPrefix" name:"internal-registry-prefix" usage:"The image prefix to use when pushing internal images (example ghcr.io/my-org/)"`
+	AppRevisionHistoryLimit      *int           `json:"appRevisionHistoryLimit" name:"app-revision-history-limit" usage:"The number of app revisions to keep for rollback (default 10)"`
+	AutoUpgradeMaintenanceWindow *string        `json:"autoUpgradeMaintenanceWindow" name:"auto-upgrade-maintenance-window" usage:"For apps configured with automatic upgrades enabled, only apply upgrades during this window, a cron schedule followed by a duration (ex: \"0 2 * * 6 4h\"). Windows configured at the application level take precedence over this"`
+	VaultAddress                 *string        `json:"vaultAddress" name:"vault-address" usage:"The address of the HashiCorp Vault server that external secrets are read from (ex: https://vault.example.com:8200)"`
+	VaultKubernetesRole          *string        `json:"vaultKubernetesRole" name:"vault-kubernetes-role" usage:"The role to log in to Vault as with the Kubernetes auth method. If not set, the token key of the acorn-vault secret in acorn-system is used"`
+	VaultPathPrefix              *string        `json:"vaultPathPrefix" name:"vault-path-prefix" usage:"The Vault path that the external secrets of a project must be under, {{.Namespace}} is replaced with the namespace of the project (default secret/data/acorn/{{.Namespace}})"`
 }
 
 type EncryptionKey struct {
 	KeyID       string            `json:"keyID"`
 	Annotations map[string]string `json:"annotations,omitempty"`
+	Primary     bool              `json:"primary,omitempty"`
+	CreatedAt   *metav1.Time      `json:"createdAt,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EncryptionKeyRotation generates a new primary encryption key for a project. The previous keys are kept to decrypt
+// existing values until they are deleted with an EncryptionKeyDeletion.
+type EncryptionKeyRotation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// PrimaryKey is the ID of the primary key after the rotation
+	PrimaryKey string `json:"primaryKey,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// EncryptionKeyDeletion deletes retired encryption keys of a project. The keys are not deleted if values in the apps
+// of the project can only be decrypted with them, unless Force is set.
+type EncryptionKeyDeletion struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	// KeyIDs are the IDs of the retired keys to delete
+	KeyIDs []string `json:"keyIDs,omitempty"`
+	// Force deletes the keys even if values in apps can only be decrypted with them
+	Force bool `json:"force,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
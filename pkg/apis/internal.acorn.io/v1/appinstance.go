@@ -3,22 +3,26 @@
 package v1
 
 import (
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type AppInstanceCondition string
 
 var (
-	AppInstanceConditionDefined    = "defined"
-	AppInstanceConditionNamespace  = "namespace"
-	AppInstanceConditionParsed     = "parsed"
-	AppInstanceConditionController = "controller"
-	AppInstanceConditionPulled     = "image-pull"
-	AppInstanceConditionSecrets    = "secrets"
-	AppInstanceConditionContainers = "containers"
-	AppInstanceConditionJobs       = "jobs"
-	AppInstanceConditionReady      = "Ready"
-	AppInstanceConditionUpgrade    = "upgrade"
+	AppInstanceConditionDefined     = "defined"
+	AppInstanceConditionNamespace   = "namespace"
+	AppInstanceConditionParsed      = "parsed"
+	AppInstanceConditionController  = "controller"
+	AppInstanceConditionPulled      = "image-pull"
+	AppInstanceConditionSecrets     = "secrets"
+	AppInstanceConditionContainers  = "containers"
+	AppInstanceConditionJobs        = "jobs"
+	AppInstanceConditionReady       = "Ready"
+	AppInstanceConditionUpgrade     = "upgrade"
+	AppInstanceConditionRollback    = "auto-upgrade-rollback"
+	AppInstanceConditionPodSecurity = "pod-security"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -55,25 +59,30 @@ const (
 )
 
 type AppInstanceSpec struct {
-	Labels              []ScopedLabel    `json:"labels,omitempty"`
-	Annotations         []ScopedLabel    `json:"annotations,omitempty"`
-	Image               string           `json:"image,omitempty"`
-	Stop                *bool            `json:"stop,omitempty"`
-	DevMode             *bool            `json:"devMode,omitempty"`
-	Profiles            []string         `json:"profiles,omitempty"`
-	Volumes             []VolumeBinding  `json:"volumes,omitempty"`
-	Secrets             []SecretBinding  `json:"secrets,omitempty"`
-	Environment         []NameValue      `json:"environment,omitempty"`
-	PublishMode         PublishMode      `json:"publishMode,omitempty"`
-	TargetNamespace     string           `json:"targetNamespace,omitempty"`
-	Links               []ServiceBinding `json:"services,omitempty"`
-	Ports               []PortBinding    `json:"ports,omitempty"`
-	DeployArgs          GenericMap       `json:"deployArgs,omitempty"`
-	Permissions         []Permissions    `json:"permissions,omitempty"`
-	ClusterName         string           `json:"clusterName,omitempty"`
-	AutoUpgrade         *bool            `json:"autoUpgrade,omitempty"`
-	NotifyUpgrade       *bool            `json:"notifyUpgrade,omitempty"`
-	AutoUpgradeInterval string           `json:"autoUpgradeInterval,omitempty"`
+	Labels                       []ScopedLabel         `json:"labels,omitempty"`
+	Annotations                  []ScopedLabel         `json:"annotations,omitempty"`
+	Image                        string                `json:"image,omitempty"`
+	Stop                         *bool                 `json:"stop,omitempty"`
+	DevMode                      *bool                 `json:"devMode,omitempty"`
+	Profiles                     []string              `json:"profiles,omitempty"`
+	Volumes                      []VolumeBinding       `json:"volumes,omitempty"`
+	Secrets                      []SecretBinding       `json:"secrets,omitempty"`
+	Environment                  []NameValue           `json:"environment,omitempty"`
+	PublishMode                  PublishMode           `json:"publishMode,omitempty"`
+	TargetNamespace              string                `json:"targetNamespace,omitempty"`
+	Links                        []ServiceBinding      `json:"services,omitempty"`
+	Ports                        []PortBinding         `json:"ports,omitempty"`
+	DeployArgs                   GenericMap            `json:"deployArgs,omitempty"`
+	Permissions                  []Permissions         `json:"permissions,omitempty"`
+	ClusterName                  string                `json:"clusterName,omitempty"`
+	AutoUpgrade                  *bool                 `json:"autoUpgrade,omitempty"`
+	NotifyUpgrade                *bool                 `json:"notifyUpgrade,omitempty"`
+	AutoUpgradeInterval          string                `json:"autoUpgradeInterval,omitempty"`
+	AutoUpgradeRollbackAfter     string                `json:"autoUpgradeRollbackAfter,omitempty"`
+	AutoUpgradeMaintenanceWindow string                `json:"autoUpgradeMaintenanceWindow,omitempty"`
+	Memory                       []ResourceBinding     `json:"memory,omitempty"`
+	CPU                          []ResourceBinding     `json:"cpu,omitempty"`
+	NodeSelectors                []NodeSelectorBinding `json:"nodeSelectors,omitempty"`
 }
 
 func (in *AppInstanceSpec) GetAutoUpgrade() bool {
@@ -116,6 +125,22 @@ type SecretBinding struct {
 
 type Quantity string
 
+// ResourceBinding overrides the compute resource of the container named Target. An empty
+// Target applies to all containers that do not have a more specific binding.
+type ResourceBinding struct {
+	Target  string `json:"target,omitempty"`
+	Request string `json:"request,omitempty"`
+	Limit   string `json:"limit,omitempty"`
+}
+
+// NodeSelectorBinding adds the node selector Key=Value to the pods of the container or job named Target. An
+// empty Target applies to all containers and jobs.
+type NodeSelectorBinding struct {
+	Target string `json:"target,omitempty"`
+	Key    string `json:"key,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
 type VolumeBinding struct {
 	Volume      string      `json:"volume,omitempty"`
 	Target      string      `json:"target,omitempty"`
@@ -125,11 +150,13 @@ type VolumeBinding struct {
 }
 
 type ContainerStatus struct {
-	Ready        int32 `json:"ready,omitempty"`
-	ReadyDesired int32 `json:"readyDesired,omitempty"`
-	UpToDate     int32 `json:"upToDate,omitempty"`
-	RestartCount int32 `json:"restartCount,omitempty"`
-	Created      bool  `json:"created,omitempty"`
+	Ready           int32 `json:"ready,omitempty"`
+	ReadyDesired    int32 `json:"readyDesired,omitempty"`
+	UpToDate        int32 `json:"upToDate,omitempty"`
+	RestartCount    int32 `json:"restartCount,omitempty"`
+	Created         bool  `json:"created,omitempty"`
+	CurrentReplicas int32 `json:"currentReplicas,omitempty"`
+	DesiredReplicas int32 `json:"desiredReplicas,omitempty"`
 }
 
 type JobStatus struct {
@@ -142,6 +169,7 @@ type JobStatus struct {
 type AppColumns struct {
 	Healthy   string `json:"healthy,omitempty" column:"name=Healthy,jsonpath=.status.columns.healthy"`
 	UpToDate  string `json:"upToDate,omitempty" column:"name=Up-To-Date,jsonpath=.status.columns.upToDate"`
+	Replicas  string `json:"replicas,omitempty" column:"name=Replicas,jsonpath=.status.columns.replicas"`
 	Message   string `json:"message,omitempty" column:"name=Message,jsonpath=.status.columns.message"`
 	Endpoints string `json:"endpoints,omitempty" column:"name=Endpoints,jsonpath=.status.columns.endpoints"`
 	Created   string `json:"created,omitempty" column:"name=Created,jsonpath=.metadata.creationTimestamp"`
@@ -158,12 +186,36 @@ type AppInstanceStatus struct {
 	AppImage               AppImage                   `json:"appImage,omitempty"`
 	AvailableAppImage      string                     `json:"availableAppImage,omitempty"`
 	ConfirmUpgradeAppImage string                     `json:"confirmUpgradeAppImage,omitempty"`
+	AutoUpgradeRollback    *AutoUpgradeRollbackStatus `json:"autoUpgradeRollback,omitempty"`
+	NextMaintenanceWindow  *metav1.Time               `json:"nextMaintenanceWindow,omitempty"`
 	AppSpec                AppSpec                    `json:"appSpec,omitempty"`
 	Conditions             []Condition                `json:"conditions,omitempty"`
 	Endpoints              []Endpoint                 `json:"endpoints,omitempty"`
 	Placement              string                     `json:"placement,omitempty"`
 }
 
+// AutoUpgradeRollbackStatus tracks an automatic upgrade until it becomes ready, and the images that were
+// rolled back because they didn't.
+type AutoUpgradeRollbackStatus struct {
+	// PreviousAppImage is the image that was running before the upgrade, it is only set while the upgrade is pending
+	PreviousAppImage *AppImage   `json:"previousAppImage,omitempty"`
+	UpgradeTime      metav1.Time `json:"upgradeTime,omitempty"`
+	// SkippedImages are image tags or digests that auto-upgrade will not upgrade to again
+	SkippedImages []string `json:"skippedImages,omitempty"`
+}
+
+func (in *AutoUpgradeRollbackStatus) IsSkipped(image, digest string) bool {
+	if in == nil {
+		return false
+	}
+	for _, skipped := range in.SkippedImages {
+		if skipped == image || (digest != "" && strings.TrimPrefix(skipped, "sha256:") == strings.TrimPrefix(digest, "sha256:")) {
+			return true
+		}
+	}
+	return false
+}
+
 type Endpoint struct {
 	Target     string   `json:"target,omitempty"`
 	TargetPort int32    `json:"targetPort,omitempty"`
@@ -0,0 +1,26 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type AppRevisionInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AppRevisionInstance `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AppRevisionInstance is an immutable record of the spec and the resolved app image of an
+// AppInstance. A new revision is recorded each time either of them changes.
+type AppRevisionInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Revision int64           `json:"revision,omitempty"`
+	Spec     AppInstanceSpec `json:"spec,omitempty"`
+	AppImage AppImage        `json:"appImage,omitempty"`
+}
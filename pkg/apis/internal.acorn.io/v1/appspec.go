@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -157,6 +158,11 @@ type VolumeMount struct {
 	SubPath    string            `json:"subPath,omitempty"`
 	ContextDir string            `json:"contextDir,omitempty"`
 	Secret     VolumeSecretMount `json:"secret,omitempty"`
+	// SeedFrom is a context dir copied into the volume the first time it is mounted
+	SeedFrom string `json:"seedFrom,omitempty"`
+	// SeedFromImage is a reference to an image of the app, such as images.fixtures, whose content at the mount path
+	// is copied into the volume the first time it is mounted
+	SeedFromImage string `json:"seedFromImage,omitempty"`
 }
 
 type NameValue struct {
@@ -213,6 +219,70 @@ type Probe struct {
 	FailureThreshold    int32      `json:"failureThreshold,omitempty"`
 }
 
+// ComputeResource is a request and limit for a single compute resource (cpu or memory)
+// of a container. Both values are in Kubernetes quantity format, for example "512Mi" or "250m".
+type ComputeResource struct {
+	Request string `json:"request,omitempty"`
+	Limit   string `json:"limit,omitempty"`
+}
+
+// Autoscale configures a horizontal pod autoscaler for a container. TargetCPU and TargetMemory are
+// the average utilization, as a percentage of the requested resources, that the autoscaler aims for.
+type Autoscale struct {
+	Min          int32 `json:"min,omitempty"`
+	Max          int32 `json:"max,omitempty"`
+	TargetCPU    int32 `json:"targetCPU,omitempty"`
+	TargetMemory int32 `json:"targetMemory,omitempty"`
+}
+
+// SecurityContext is the subset of the Kubernetes container security context that can be declared for a
+// container. SeccompProfile is RuntimeDefault or Unconfined.
+type SecurityContext struct {
+	RunAsUser                *int64        `json:"runAsUser,omitempty"`
+	RunAsGroup               *int64        `json:"runAsGroup,omitempty"`
+	RunAsNonRoot             *bool         `json:"runAsNonRoot,omitempty"`
+	ReadOnlyRootFilesystem   *bool         `json:"readOnlyRootFilesystem,omitempty"`
+	Privileged               *bool         `json:"privileged,omitempty"`
+	AllowPrivilegeEscalation *bool         `json:"allowPrivilegeEscalation,omitempty"`
+	Capabilities             *Capabilities `json:"capabilities,omitempty"`
+	SeccompProfile           string        `json:"seccompProfile,omitempty"`
+}
+
+type Capabilities struct {
+	Add  []string `json:"add,omitempty"`
+	Drop []string `json:"drop,omitempty"`
+}
+
+// Rollout configures the rolling update of a container. MaxSurge and MaxUnavailable are a number of
+// replicas or a percentage of the desired replicas.
+type Rollout struct {
+	MaxSurge        *intstr.IntOrString `json:"maxSurge,omitempty"`
+	MaxUnavailable  *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	MinReadySeconds int32               `json:"minReadySeconds,omitempty"`
+}
+
+// Disruption configures the pod disruption budget of a container. Only one of MinAvailable and
+// MaxUnavailable can be set, either as a number of replicas or a percentage.
+type Disruption struct {
+	MinAvailable   *intstr.IntOrString `json:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+type Toleration struct {
+	Key      string `json:"key,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Effect   string `json:"effect,omitempty"`
+}
+
+// Spread is the topology the replicas of a container are spread across, either zones or nodes
+type Spread string
+
+const (
+	SpreadZone Spread = "zone"
+	SpreadNode Spread = "node"
+)
+
 type Dependency struct {
 	TargetName string `json:"targetName,omitempty"`
 }
@@ -287,10 +357,31 @@ type Container struct {
 	Probes       Probes                 `json:"probes"` // Don't omitempty so that nil vs empty is recorded
 	Dependencies Dependencies           `json:"dependencies,omitempty"`
 	Permissions  *Permissions           `json:"permissions,omitempty"`
+	Memory       *ComputeResource       `json:"memory,omitempty"`
+	CPU          *ComputeResource       `json:"cpu,omitempty"`
+
+	SecurityContext *SecurityContext `json:"securityContext,omitempty"`
+
+	// NodeSelector and Tolerations are not available on sidecars
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	Tolerations  []Toleration      `json:"tolerations,omitempty"`
+
+	// Spread is only available on containers, not sidecars or jobs
+	Spread Spread `json:"spread,omitempty"`
 
 	// Scale is only available on containers, not sidecars or jobs
 	Scale *int32 `json:"scale,omitempty"`
 
+	// Autoscale is only available on containers, not sidecars or jobs
+	Autoscale *Autoscale `json:"autoscale,omitempty"`
+
+	// Stateful is only available on containers, not sidecars or jobs
+	Stateful bool `json:"stateful,omitempty"`
+
+	// Rollout and Disruption are only available on containers, not sidecars or jobs
+	Rollout    *Rollout    `json:"rollout,omitempty"`
+	Disruption *Disruption `json:"disruption,omitempty"`
+
 	// Schedule is only available on jobs
 	Schedule string `json:"schedule,omitempty"`
 
@@ -318,10 +409,31 @@ type AppSpec struct {
 }
 
 type Route struct {
-	Path              string   `json:"path,omitempty"`
-	TargetServiceName string   `json:"targetServiceName,omitempty"`
-	TargetPort        int      `json:"targetPort,omitempty"`
-	PathType          PathType `json:"pathType,omitempty"`
+	Path              string        `json:"path,omitempty"`
+	TargetServiceName string        `json:"targetServiceName,omitempty"`
+	TargetPort        int           `json:"targetPort,omitempty"`
+	PathType          PathType      `json:"pathType,omitempty"`
+	Targets           []RouteTarget `json:"targets,omitempty"`
+	// Rewrite replaces the matched path before the request is proxied, so "/" strips the path of the route
+	Rewrite               string            `json:"rewrite,omitempty"`
+	SetRequestHeaders     map[string]string `json:"setRequestHeaders,omitempty"`
+	RemoveResponseHeaders []string          `json:"removeResponseHeaders,omitempty"`
+	Redirect              *RouteRedirect    `json:"redirect,omitempty"`
+}
+
+// RouteRedirect answers the requests of a route with a redirect instead of proxying them to a target
+type RouteRedirect struct {
+	URL        string `json:"url,omitempty"`
+	StatusCode int    `json:"statusCode,omitempty"`
+}
+
+// RouteTarget is one of the services a route splits traffic between. Requests that have all the headers of a target
+// are sent to it, the rest are split between the targets by weight.
+type RouteTarget struct {
+	TargetServiceName string            `json:"targetServiceName,omitempty"`
+	TargetPort        int               `json:"targetPort,omitempty"`
+	Weight            int               `json:"weight,omitempty"`
+	Headers           map[string]string `json:"headers,omitempty"`
 }
 
 type Routes []Route
@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ParseNodeSelectors parses arguments of the format [container:]key=value
+func ParseNodeSelectors(args []string) (result []NodeSelectorBinding, _ error) {
+	for _, arg := range args {
+		target, selector, ok := strings.Cut(arg, ":")
+		if !ok {
+			selector = target
+			target = ""
+		}
+		key, value, ok := strings.Cut(selector, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid node selector [%s] must be of the format [container:]key=value", arg)
+		}
+		binding := NodeSelectorBinding{
+			Target: strings.TrimSpace(target),
+			Key:    strings.TrimSpace(key),
+			Value:  strings.TrimSpace(value),
+		}
+		if err := binding.Validate(); err != nil {
+			return nil, fmt.Errorf("parsing [%s]: %w", arg, err)
+		}
+		result = append(result, binding)
+	}
+	return
+}
+
+// Validate ensures the key and value are valid for a node label
+func (in NodeSelectorBinding) Validate() error {
+	if errs := validation.IsQualifiedName(in.Key); len(errs) > 0 {
+		return fmt.Errorf("invalid key [%s]: %s", in.Key, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsValidLabelValue(in.Value); len(errs) > 0 {
+		return fmt.Errorf("invalid value [%s]: %s", in.Value, strings.Join(errs, ", "))
+	}
+	return nil
+}
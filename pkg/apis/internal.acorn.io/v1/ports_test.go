@@ -51,3 +51,21 @@ func TestParseVolumes(t *testing.T) {
 		Class:  "aclass",
 	}, vs[1])
 }
+
+func TestParseNodeSelectors(t *testing.T) {
+	ns, err := ParseNodeSelectors([]string{
+		"pool=gpu",
+		"web:kubernetes.io/arch=arm64",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []NodeSelectorBinding{
+		{Key: "pool", Value: "gpu"},
+		{Target: "web", Key: "kubernetes.io/arch", Value: "arm64"},
+	}, ns)
+
+	_, err = ParseNodeSelectors([]string{"web:pool"})
+	assert.Error(t, err)
+
+	_, err = ParseNodeSelectors([]string{"pool=not valid"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ParseResourceBindings parses arguments of the format [container=]quantity. The quantity is used as
+// both the request and the limit.
+func ParseResourceBindings(args []string) (result []ResourceBinding, _ error) {
+	for _, arg := range args {
+		target, quantity, ok := strings.Cut(arg, "=")
+		if !ok {
+			quantity = target
+			target = ""
+		}
+		target = strings.TrimSpace(target)
+		quantity = strings.TrimSpace(quantity)
+		if quantity == "" {
+			return nil, fmt.Errorf("invalid resource binding [%s] must not have zero length value", arg)
+		}
+		if _, err := resource.ParseQuantity(quantity); err != nil {
+			return nil, fmt.Errorf("parsing [%s]: %w", arg, err)
+		}
+		result = append(result, ResourceBinding{
+			Target:  target,
+			Request: quantity,
+			Limit:   quantity,
+		})
+	}
+	return
+}
+
+// FindResourceBinding returns the binding that applies to the named container. A binding that targets
+// the container by name takes precedence over one with no target.
+func FindResourceBinding(containerName string, bindings []ResourceBinding) (ResourceBinding, bool) {
+	var (
+		result ResourceBinding
+		found  bool
+	)
+	for _, binding := range bindings {
+		if binding.Target == containerName {
+			return binding, true
+		} else if binding.Target == "" {
+			result = binding
+			found = true
+		}
+	}
+	return result, found
+}
+
+// Validate ensures the request and limit are valid quantities and that the request does not exceed the limit
+func (in ComputeResource) Validate() error {
+	var (
+		request, limit resource.Quantity
+		err            error
+	)
+	if in.Request != "" {
+		request, err = resource.ParseQuantity(in.Request)
+		if err != nil {
+			return fmt.Errorf("invalid request [%s]: %w", in.Request, err)
+		}
+	}
+	if in.Limit != "" {
+		limit, err = resource.ParseQuantity(in.Limit)
+		if err != nil {
+			return fmt.Errorf("invalid limit [%s]: %w", in.Limit, err)
+		}
+	}
+	if in.Request != "" && in.Limit != "" && request.Cmp(limit) > 0 {
+		return fmt.Errorf("request [%s] must not be greater than limit [%s]", in.Request, in.Limit)
+	}
+	return nil
+}
@@ -9,6 +9,15 @@ const (
 	SecretTypeTemplate  corev1.SecretType = "secrets.acorn.io/template"
 	SecretTypeBasic     corev1.SecretType = "secrets.acorn.io/basic"
 	SecretTypeToken     corev1.SecretType = "secrets.acorn.io/token"
+	SecretTypeTLS       corev1.SecretType = "secrets.acorn.io/tls"
+	SecretTypeSSHKey    corev1.SecretType = "secrets.acorn.io/ssh-key"
+	SecretTypeKeyPair   corev1.SecretType = "secrets.acorn.io/keypair"
+	SecretTypeExternal  corev1.SecretType = "secrets.acorn.io/external"
+
+	SSHKeyPrivateKey  = corev1.SSHAuthPrivateKey
+	SSHKeyPublicKey   = "ssh-publickey"
+	KeyPairPrivateKey = "private.pem"
+	KeyPairPublicKey  = "public.pem"
 )
 
 var (
@@ -18,5 +27,15 @@ var (
 		SecretTypeTemplate:  true,
 		SecretTypeBasic:     true,
 		SecretTypeToken:     true,
+		SecretTypeTLS:       true,
+		SecretTypeSSHKey:    true,
+		SecretTypeKeyPair:   true,
+		SecretTypeExternal:  true,
+	}
+
+	// SecretPrivateKeys are the keys of secrets that are never exposed through the API
+	SecretPrivateKeys = map[corev1.SecretType]string{
+		SecretTypeSSHKey:  SSHKeyPrivateKey,
+		SecretTypeKeyPair: KeyPairPrivateKey,
 	}
 )
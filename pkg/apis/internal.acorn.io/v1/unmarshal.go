@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -26,9 +27,15 @@ var (
 )
 
 type routeTarget struct {
-	PathType          PathType `json:"pathType,omitempty"`
-	TargetPort        int      `json:"targetPort,omitempty"`
-	TargetServiceName string   `json:"targetServiceName,omitempty"`
+	PathType          PathType      `json:"pathType,omitempty"`
+	TargetPort        int           `json:"targetPort,omitempty"`
+	TargetServiceName string        `json:"targetServiceName,omitempty"`
+	Targets           []RouteTarget `json:"targets,omitempty"`
+
+	Rewrite               string            `json:"rewrite,omitempty"`
+	SetRequestHeaders     map[string]string `json:"setRequestHeaders,omitempty"`
+	RemoveResponseHeaders []string          `json:"removeResponseHeaders,omitempty"`
+	Redirect              *RouteRedirect    `json:"redirect,omitempty"`
 }
 
 func (in *routeTarget) UnmarshalJSON(data []byte) error {
@@ -56,6 +63,21 @@ func (in *routeTarget) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (in *RouteRedirect) UnmarshalJSON(data []byte) error {
+	if !isString(data) {
+		type routeRedirectType RouteRedirect
+		return json.Unmarshal(data, (*routeRedirectType)(in))
+	}
+
+	s, err := parseString(data)
+	if err != nil {
+		return err
+	}
+	in.URL = s
+	in.StatusCode = http.StatusFound
+	return nil
+}
+
 func (in *Routes) UnmarshalJSON(data []byte) error {
 	if !isObject(data) {
 		type routesType Routes
@@ -73,6 +95,12 @@ func (in *Routes) UnmarshalJSON(data []byte) error {
 			TargetServiceName: v.TargetServiceName,
 			TargetPort:        v.TargetPort,
 			PathType:          v.PathType,
+			Targets:           v.Targets,
+
+			Rewrite:               v.Rewrite,
+			SetRequestHeaders:     v.SetRequestHeaders,
+			RemoveResponseHeaders: v.RemoveResponseHeaders,
+			Redirect:              v.Redirect,
 		})
 	}
 	sort.Slice(routes, func(i, j int) bool {
@@ -159,6 +187,36 @@ func (in *Quantity) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+func (in *ComputeResource) UnmarshalJSON(data []byte) error {
+	if isObject(data) {
+		type computeResource ComputeResource
+		if err := json.Unmarshal(data, (*computeResource)(in)); err != nil {
+			return err
+		}
+		return in.Validate()
+	}
+
+	var s string
+	if isString(data) {
+		str, err := parseString(data)
+		if err != nil {
+			return err
+		}
+		s = str
+	} else {
+		var num json.Number
+		if err := json.Unmarshal(data, &num); err != nil {
+			return err
+		}
+		s = num.String()
+	}
+
+	// The short form is used as both the request and the limit
+	in.Request = s
+	in.Limit = s
+	return in.Validate()
+}
+
 func (in *ServiceBinding) UnmarshalJSON(data []byte) error {
 	if !isString(data) {
 		type serviceBinding ServiceBinding
@@ -382,9 +440,139 @@ func (in *AppSpec) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	if err := checkSeedImages(in); err != nil {
+		return err
+	}
+
+	if err := checkStatefulVolumes(in); err != nil {
+		return err
+	}
+
+	if err := checkRollouts(in); err != nil {
+		return err
+	}
+
 	return checkForDuplicateNames(in)
 }
 
+func checkSeedImagesForContainer(in *AppSpec, c Container) error {
+	for path, dir := range c.Dirs {
+		if dir.SeedFromImage == "" {
+			continue
+		}
+		if _, ok := in.Images[strings.TrimPrefix(dir.SeedFromImage, "images.")]; !ok {
+			return fmt.Errorf("failed to find image %s to seed %s", dir.SeedFromImage, path)
+		}
+	}
+	for _, sidecar := range c.Sidecars {
+		if err := checkSeedImagesForContainer(in, sidecar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkSeedImages(in *AppSpec) error {
+	for _, c := range in.Containers {
+		if err := checkSeedImagesForContainer(in, c); err != nil {
+			return err
+		}
+	}
+	for _, j := range in.Jobs {
+		if err := checkSeedImagesForContainer(in, j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func volumesOf(in *AppSpec, c Container) (result []string) {
+	for _, dir := range c.Dirs {
+		if dir.Volume == "" || dir.Secret.Name != "" || dir.ContextDir != "" ||
+			in.Volumes[dir.Volume].Class == VolumeRequestTypeEphemeral {
+			continue
+		}
+		result = append(result, dir.Volume)
+	}
+	for _, sidecar := range c.Sidecars {
+		result = append(result, volumesOf(in, sidecar)...)
+	}
+	return
+}
+
+// checkStatefulVolumes ensures volumes of stateful containers are not shared, as each replica of a stateful
+// container gets its own copy of the volume
+func checkStatefulVolumes(in *AppSpec) error {
+	owners := map[string]string{}
+	for name, c := range in.Containers {
+		if c.Stateful {
+			for _, volume := range volumesOf(in, c) {
+				owners[volume] = name
+			}
+		}
+	}
+
+	check := func(name string, c Container) error {
+		for _, volume := range volumesOf(in, c) {
+			if owner, ok := owners[volume]; ok && owner != name {
+				return fmt.Errorf("volume %s of stateful container %s can not be used by %s", volume, owner, name)
+			}
+		}
+		return nil
+	}
+	for name, c := range in.Containers {
+		if err := check(name, c); err != nil {
+			return err
+		}
+	}
+	for name, j := range in.Jobs {
+		if err := check(name, j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isRecreated matches the containers that are stopped before they are replaced, as they use a persistent
+// volume that can only be attached to one replica
+func isRecreated(in *AppSpec, c Container) bool {
+	if c.Stateful {
+		return false
+	}
+	for _, dir := range c.Dirs {
+		if dir.Volume == "" || dir.Secret.Name != "" {
+			continue
+		}
+		vol, ok := in.Volumes[dir.Volume]
+		if !ok || vol.Class == VolumeRequestTypeEphemeral {
+			continue
+		}
+		if len(vol.AccessModes) == 0 || (len(vol.AccessModes) == 1 && vol.AccessModes[0] == AccessModeReadWriteOnce) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRollouts rejects the rollout settings that can not be applied to a container. Stateful containers replace
+// their replicas in place, so they can not surge, and recreated containers have no rolling update at all.
+func checkRollouts(in *AppSpec) error {
+	for name, c := range in.Containers {
+		rollout := c.Rollout
+		if rollout == nil {
+			continue
+		}
+		if c.Stateful && rollout.MaxSurge != nil {
+			return fmt.Errorf("rollout maxSurge can not be set on stateful container %s", name)
+		}
+		if (rollout.MaxSurge != nil || rollout.MaxUnavailable != nil) && isRecreated(in, c) {
+			return fmt.Errorf("rollout maxSurge and maxUnavailable can not be set on container %s, it is stopped before "+
+				"it is replaced because it uses a persistent volume", name)
+		}
+	}
+	return nil
+}
+
 func addName(data map[string]string, key, value string) error {
 	existing := data[key]
 	if existing != "" && existing != value {
@@ -476,6 +664,9 @@ func adjustBuildForContextDirs(c Container) *Build {
 	for path, dir := range c.Dirs {
 		if dir.ContextDir != "" {
 			dirs[path] = dir.ContextDir
+		} else if dir.SeedFrom != "" {
+			// The seed content is copied into the image at the mount path, where the seed init container reads it
+			dirs[path] = dir.SeedFrom
 		}
 	}
 
@@ -639,7 +830,13 @@ func (in *Ports) UnmarshalJSON(data []byte) error {
 func (in *VolumeMount) UnmarshalJSON(data []byte) error {
 	if !isString(data) {
 		type volumeMount VolumeMount
-		return json.Unmarshal(data, (*volumeMount)(in))
+		if err := json.Unmarshal(data, (*volumeMount)(in)); err != nil {
+			return err
+		}
+		if in.SeedFrom != "" && in.SeedFromImage != "" {
+			return fmt.Errorf("only one of seedFrom or seedFromImage can be set for volume %s", in.Volume)
+		}
+		return nil
 	}
 
 	s, err := parseString(data)
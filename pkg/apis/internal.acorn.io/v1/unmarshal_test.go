@@ -47,3 +47,32 @@ func TestParseEnv(t *testing.T) {
 		Value: "y111",
 	}, f[1])
 }
+
+func TestParseResourceBindings(t *testing.T) {
+	f, err := ParseResourceBindings([]string{"512Mi", "web=1Gi"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []ResourceBinding{
+		{
+			Request: "512Mi",
+			Limit:   "512Mi",
+		},
+		{
+			Target:  "web",
+			Request: "1Gi",
+			Limit:   "1Gi",
+		},
+	}, f)
+
+	_, err = ParseResourceBindings([]string{"web=lots"})
+	assert.Error(t, err)
+
+	binding, ok := FindResourceBinding("web", f)
+	assert.True(t, ok)
+	assert.Equal(t, "1Gi", binding.Request)
+
+	binding, ok = FindResourceBinding("db", f)
+	assert.True(t, ok)
+	assert.Equal(t, "512Mi", binding.Request)
+}
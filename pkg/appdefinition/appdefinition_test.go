@@ -12,6 +12,7 @@ import (
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/baaah/pkg/typed"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestParseRouters(t *testing.T) {
@@ -105,6 +106,167 @@ routers: {
 	}, spec.Routers["foo"])
 }
 
+func TestParseWeightedRouters(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+routers: {
+	slice: {
+		routes: [
+			{
+				path: "/"
+				targets: [
+					{
+						targetServiceName: "web"
+						weight: 90
+					},
+					{
+						targetServiceName: "web-canary"
+						targetPort: 8080
+						weight: 10
+						headers: "X-Canary": "true"
+					},
+				]
+			},
+		]
+	}
+	foo: {
+		routes: {
+			"/api": targets: [
+				{targetServiceName: "api"},
+				{targetServiceName: "api-next"},
+			]
+		}
+	}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := appImage.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []v1.Route{
+		{
+			Path:     "/",
+			PathType: v1.PathTypePrefix,
+			Targets: []v1.RouteTarget{
+				{
+					TargetServiceName: "web",
+					Weight:            90,
+					Headers:           map[string]string{},
+				},
+				{
+					TargetServiceName: "web-canary",
+					TargetPort:        8080,
+					Weight:            10,
+					Headers: map[string]string{
+						"X-Canary": "true",
+					},
+				},
+			},
+		},
+	}, []v1.Route(spec.Routers["slice"].Routes))
+
+	assert.Equal(t, []v1.Route{
+		{
+			Path:     "/api",
+			PathType: v1.PathTypePrefix,
+			Targets: []v1.RouteTarget{
+				{
+					TargetServiceName: "api",
+					Weight:            1,
+					Headers:           map[string]string{},
+				},
+				{
+					TargetServiceName: "api-next",
+					Weight:            1,
+					Headers:           map[string]string{},
+				},
+			},
+		},
+	}, []v1.Route(spec.Routers["foo"].Routes))
+
+	// A route has either a single target or weighted targets
+	appImage, err = NewAppDefinition([]byte(`
+routers: foo: routes: "/": {
+	targetServiceName: "web"
+	targets: [{targetServiceName: "web-canary"}]
+}`))
+	if err == nil {
+		_, err = appImage.AppSpec()
+	}
+	assert.Error(t, err)
+}
+
+func TestParseRewriteAndRedirectRouters(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+routers: foo: routes: {
+	"/api": {
+		targetServiceName: "api"
+		rewrite: "/"
+		setRequestHeaders: "X-Forwarded-Prefix": "/api"
+		removeResponseHeaders: ["X-Powered-By"]
+	}
+	"/old": redirect: "https://example.com/new"
+	"/moved": {
+		pathType: "exact"
+		redirect: {
+			url: "https://example.com/moved"
+			statusCode: 301
+		}
+	}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec, err := appImage.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []v1.Route{
+		{
+			Path:     "/moved",
+			PathType: v1.PathTypeExact,
+			Redirect: &v1.RouteRedirect{
+				URL:        "https://example.com/moved",
+				StatusCode: 301,
+			},
+		},
+		{
+			Path:              "/api",
+			PathType:          v1.PathTypePrefix,
+			TargetServiceName: "api",
+			Rewrite:           "/",
+			SetRequestHeaders: map[string]string{
+				"X-Forwarded-Prefix": "/api",
+			},
+			RemoveResponseHeaders: []string{"X-Powered-By"},
+		},
+		{
+			Path:     "/old",
+			PathType: v1.PathTypePrefix,
+			Redirect: &v1.RouteRedirect{
+				URL:        "https://example.com/new",
+				StatusCode: 302,
+			},
+		},
+	}, []v1.Route(spec.Routers["foo"].Routes))
+
+	// A redirect doesn't have a target
+	appImage, err = NewAppDefinition([]byte(`
+routers: foo: routes: "/": {
+	targetServiceName: "web"
+	redirect: "https://example.com"
+}`))
+	if err == nil {
+		_, err = appImage.AppSpec()
+	}
+	assert.Error(t, err)
+}
+
 func TestParse5GLiteralVolume(t *testing.T) {
 	appImage, err := NewAppDefinition([]byte(`
 volumes: {
@@ -1358,6 +1520,118 @@ secrets: {
 
 }
 
+func TestTLSSecret(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+secrets: {
+  "default-tls": type: "tls"
+  "api-tls": {
+    type: "tls"
+    params: {
+      sans: ["api.example.com"]
+      algorithm: "rsa"
+      validity: "720h"
+      ca: "project"
+    }
+  }
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := appImage.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, v1.GenericMap{
+		"sans":      []interface{}{},
+		"algorithm": "ecdsa",
+		"validity":  "2160h",
+		"ca":        "app",
+	}, appSpec.Secrets["default-tls"].Params)
+	assert.Equal(t, v1.GenericMap{
+		"sans":      []interface{}{"api.example.com"},
+		"algorithm": "rsa",
+		"validity":  "720h",
+		"ca":        "project",
+	}, appSpec.Secrets["api-tls"].Params)
+
+	_, err = NewAppDefinition([]byte(`secrets: tls: {
+  type: "tls"
+  params: algorithm: "dsa"
+}`))
+	assert.Error(t, err)
+}
+
+func TestTemplateSecretEngine(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+secrets: {
+  default: {
+    type: "template"
+    data: key: "${secret://token/token}"
+  }
+  go: {
+    type: "template"
+    params: engine: "go"
+    data: key: "{{ secret \"token\" \"token\" | urlEncode }}"
+  }
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := appImage.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, v1.GenericMap{"engine": "substitution"}, appSpec.Secrets["default"].Params)
+	assert.Equal(t, v1.GenericMap{"engine": "go"}, appSpec.Secrets["go"].Params)
+
+	_, err = NewAppDefinition([]byte(`secrets: tmpl: {
+  type: "template"
+  params: engine: "jinja"
+}`))
+	assert.Error(t, err)
+}
+
+func TestExternalSecret(t *testing.T) {
+	appImage, err := NewAppDefinition([]byte(`
+secrets: db: {
+  type: "external"
+  params: path: "kv/data/db"
+}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := appImage.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, v1.GenericMap{
+		"provider": "vault",
+		"path":     "kv/data/db",
+		"refresh":  "1h",
+	}, appSpec.Secrets["db"].Params)
+
+	_, err = NewAppDefinition([]byte(`secrets: db: type: "external"`))
+	assert.Error(t, err)
+
+	_, err = NewAppDefinition([]byte(`secrets: db: {
+  type: "external"
+  params: {
+    provider: "aws"
+    path: "db"
+  }
+}`))
+	assert.Error(t, err)
+}
+
 func TestImageDataOverride(t *testing.T) {
 	acornCue := `
 containers: db: image: "mariadb"
@@ -1498,6 +1772,302 @@ containers: zero: scale: 0
 	assert.Equal(t, int32(0), *appSpec.Containers["zero"].Scale)
 }
 
+func TestAutoscale(t *testing.T) {
+	acornCue := `
+containers: nil: {}
+containers: min: autoscale: max: 3
+containers: full: autoscale: {
+	min: 2
+	max: 10
+	targetCPU: 70
+	targetMemory: 80
+}
+`
+	def, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := def.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, appSpec.Containers["nil"].Autoscale)
+	assert.Equal(t, &v1.Autoscale{Min: 1, Max: 3}, appSpec.Containers["min"].Autoscale)
+	assert.Equal(t, &v1.Autoscale{Min: 2, Max: 10, TargetCPU: 70, TargetMemory: 80}, appSpec.Containers["full"].Autoscale)
+}
+
+func TestAutoscaleInvalid(t *testing.T) {
+	_, err := NewAppDefinition([]byte(`containers: foo: autoscale: {min: 5, max: 2}`))
+	assert.NotNil(t, err)
+}
+
+func TestComputeResources(t *testing.T) {
+	acornCue := `
+containers: nil: {}
+containers: short: {
+	memory: "512Mi"
+	cpu: 0.5
+	sidecars: side: memory: "128Mi"
+}
+containers: long: {
+	memory: {
+		request: "256Mi"
+		limit: "1Gi"
+	}
+	cpu: request: "250m"
+}
+jobs: job: memory: "64Mi"
+`
+	def, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := def.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, appSpec.Containers["nil"].Memory)
+	assert.Nil(t, appSpec.Containers["nil"].CPU)
+	assert.Equal(t, &v1.ComputeResource{Request: "512Mi", Limit: "512Mi"}, appSpec.Containers["short"].Memory)
+	assert.Equal(t, &v1.ComputeResource{Request: "0.5", Limit: "0.5"}, appSpec.Containers["short"].CPU)
+	assert.Equal(t, &v1.ComputeResource{Request: "128Mi", Limit: "128Mi"}, appSpec.Containers["short"].Sidecars["side"].Memory)
+	assert.Equal(t, &v1.ComputeResource{Request: "256Mi", Limit: "1Gi"}, appSpec.Containers["long"].Memory)
+	assert.Equal(t, &v1.ComputeResource{Request: "250m"}, appSpec.Containers["long"].CPU)
+	assert.Equal(t, &v1.ComputeResource{Request: "64Mi", Limit: "64Mi"}, appSpec.Jobs["job"].Memory)
+}
+
+func TestRolloutAndDisruption(t *testing.T) {
+	acornCue := `
+containers: nil: {}
+containers: web: {
+	rollout: {
+		maxSurge: 1
+		maxUnavailable: "25%"
+		minReadySeconds: 10
+	}
+	disruption: minAvailable: 2
+}
+containers: api: disruption: maxUnavailable: "50%"
+`
+	def, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := def.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, appSpec.Containers["nil"].Rollout)
+	assert.Nil(t, appSpec.Containers["nil"].Disruption)
+	assert.Equal(t, &v1.Rollout{
+		MaxSurge:        &[]intstr.IntOrString{intstr.FromInt(1)}[0],
+		MaxUnavailable:  &[]intstr.IntOrString{intstr.FromString("25%")}[0],
+		MinReadySeconds: 10,
+	}, appSpec.Containers["web"].Rollout)
+	assert.Equal(t, &v1.Disruption{
+		MinAvailable: &[]intstr.IntOrString{intstr.FromInt(2)}[0],
+	}, appSpec.Containers["web"].Disruption)
+	assert.Equal(t, &v1.Disruption{
+		MaxUnavailable: &[]intstr.IntOrString{intstr.FromString("50%")}[0],
+	}, appSpec.Containers["api"].Disruption)
+}
+
+func TestRolloutAndDisruptionInvalid(t *testing.T) {
+	_, err := NewAppDefinition([]byte(`containers: foo: disruption: {minAvailable: 1, maxUnavailable: 1}`))
+	assert.NotNil(t, err)
+
+	_, err = NewAppDefinition([]byte(`containers: foo: rollout: maxSurge: "lots"`))
+	assert.NotNil(t, err)
+
+	_, err = NewAppDefinition([]byte(`jobs: foo: disruption: minAvailable: 1`))
+	assert.NotNil(t, err)
+
+	_, err = NewAppDefinition([]byte(`
+containers: db: {
+	stateful: true
+	rollout: maxSurge: 1
+}
+`))
+	assert.EqualError(t, err, "rollout maxSurge can not be set on stateful container db")
+
+	_, err = NewAppDefinition([]byte(`
+containers: web: {
+	dirs: "/data": "data"
+	rollout: maxUnavailable: 1
+}
+`))
+	assert.EqualError(t, err, "rollout maxSurge and maxUnavailable can not be set on container web, it is stopped "+
+		"before it is replaced because it uses a persistent volume")
+
+	_, err = NewAppDefinition([]byte(`
+containers: web: {
+	dirs: "/data": "data"
+	rollout: minReadySeconds: 10
+}
+containers: db: {
+	stateful: true
+	dirs: "/var/lib/data": "db"
+	rollout: maxUnavailable: 1
+}
+containers: api: {
+	dirs: "/tmp": "ephemeral://scratch"
+	rollout: maxSurge: 1
+}
+volumes: shared: accessModes: "readWriteMany"
+containers: worker: {
+	dirs: "/data": "shared"
+	rollout: maxSurge: 1
+}
+`))
+	assert.NoError(t, err)
+}
+
+func TestPlacement(t *testing.T) {
+	acornCue := `
+containers: nil: {}
+containers: web: {
+	nodeSelector: "kubernetes.io/arch": "arm64"
+	tolerations: [{key: "dedicated", value: "web", effect: "NoSchedule"}]
+	spread: "node"
+}
+jobs: job: tolerations: [{key: "gpu", operator: "Exists"}]
+`
+	def, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := def.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, appSpec.Containers["nil"].NodeSelector)
+	assert.Empty(t, appSpec.Containers["nil"].Tolerations)
+	assert.Equal(t, v1.Spread(""), appSpec.Containers["nil"].Spread)
+	assert.Equal(t, map[string]string{"kubernetes.io/arch": "arm64"}, appSpec.Containers["web"].NodeSelector)
+	assert.Equal(t, []v1.Toleration{{Key: "dedicated", Value: "web", Effect: "NoSchedule"}}, appSpec.Containers["web"].Tolerations)
+	assert.Equal(t, v1.SpreadNode, appSpec.Containers["web"].Spread)
+	assert.Equal(t, []v1.Toleration{{Key: "gpu", Operator: "Exists"}}, appSpec.Jobs["job"].Tolerations)
+}
+
+func TestPlacementInvalid(t *testing.T) {
+	_, err := NewAppDefinition([]byte(`containers: foo: spread: "region"`))
+	assert.NotNil(t, err)
+
+	_, err = NewAppDefinition([]byte(`jobs: foo: spread: "node"`))
+	assert.NotNil(t, err)
+
+	_, err = NewAppDefinition([]byte(`containers: foo: sidecars: bar: nodeSelector: foo: "bar"`))
+	assert.NotNil(t, err)
+}
+
+func TestStateful(t *testing.T) {
+	acornCue := `
+containers: web: dirs: "/cache": "cache"
+containers: db: {
+	stateful: true
+	scale: 3
+	dirs: {
+		"/var/lib/data": "data"
+		"/tmp": "ephemeral://scratch"
+	}
+}
+jobs: cleanup: dirs: "/tmp": "scratch"
+`
+	def, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := def.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, appSpec.Containers["web"].Stateful)
+	assert.True(t, appSpec.Containers["db"].Stateful)
+}
+
+func TestStatefulSharedVolume(t *testing.T) {
+	_, err := NewAppDefinition([]byte(`jobs: foo: stateful: true`))
+	assert.NotNil(t, err)
+
+	_, err = NewAppDefinition([]byte(`
+containers: db: {
+	stateful: true
+	dirs: "/var/lib/data": "data"
+}
+jobs: backup: dirs: "/data": "data"
+`))
+	assert.EqualError(t, err, "volume data of stateful container db can not be used by backup")
+}
+
+func TestSecurityContext(t *testing.T) {
+	acornCue := `
+containers: nil: {}
+containers: web: {
+	securityContext: {
+		runAsUser: 1000
+		runAsNonRoot: true
+		readOnlyRootFilesystem: true
+		allowPrivilegeEscalation: false
+		capabilities: drop: ["ALL"]
+		seccompProfile: "RuntimeDefault"
+	}
+	sidecars: side: securityContext: privileged: true
+}
+jobs: job: securityContext: runAsGroup: 2000
+`
+	def, err := NewAppDefinition([]byte(acornCue))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := def.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Nil(t, appSpec.Containers["nil"].SecurityContext)
+	assert.Equal(t, &v1.SecurityContext{
+		RunAsUser:                &[]int64{1000}[0],
+		RunAsNonRoot:             &[]bool{true}[0],
+		ReadOnlyRootFilesystem:   &[]bool{true}[0],
+		AllowPrivilegeEscalation: &[]bool{false}[0],
+		Capabilities: &v1.Capabilities{
+			Drop: []string{"ALL"},
+		},
+		SeccompProfile: "RuntimeDefault",
+	}, appSpec.Containers["web"].SecurityContext)
+	assert.Equal(t, &v1.SecurityContext{Privileged: &[]bool{true}[0]}, appSpec.Containers["web"].Sidecars["side"].SecurityContext)
+	assert.Equal(t, &v1.SecurityContext{RunAsGroup: &[]int64{2000}[0]}, appSpec.Jobs["job"].SecurityContext)
+}
+
+func TestSecurityContextInvalid(t *testing.T) {
+	_, err := NewAppDefinition([]byte(`containers: foo: securityContext: seccompProfile: "Localhost"`))
+	assert.NotNil(t, err)
+
+	_, err = NewAppDefinition([]byte(`containers: foo: securityContext: runAsUser: -1`))
+	assert.NotNil(t, err)
+}
+
+func TestComputeResourcesInvalid(t *testing.T) {
+	_, err := NewAppDefinition([]byte(`containers: foo: memory: "lots"`))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid request [lots]")
+
+	_, err = NewAppDefinition([]byte(`containers: foo: memory: {request: "2Gi", limit: "1Gi"}`))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "request [2Gi] must not be greater than limit [1Gi]")
+}
+
 func TestBuildProfileParameters(t *testing.T) {
 	acornCue := `
 args: {
@@ -2228,6 +2798,57 @@ containers: test: {
 	assert.Equal(t, "blah", appSpec.Containers["test"].Dirs["/foo3"].Volume)
 }
 
+func TestSeedVolumes(t *testing.T) {
+	data := `
+images: fixtures: image: "fixtures"
+containers: test: {
+	image: "foo"
+	dirs: "/data": {
+		volume: "db"
+		seedFrom: "./fixtures"
+	}
+	dirs: "/var/lib/app": {
+		volume: "volume://app?size=5G"
+		subPath: "state"
+		seedFromImage: "images.fixtures"
+	}
+}
+`
+	appDef, err := NewAppDefinition([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appSpec, err := appDef.AppSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	container := appSpec.Containers["test"]
+	assert.Equal(t, "db", container.Dirs["/data"].Volume)
+	assert.Equal(t, "./fixtures", container.Dirs["/data"].SeedFrom)
+	assert.Equal(t, "app", container.Dirs["/var/lib/app"].Volume)
+	assert.Equal(t, "state", container.Dirs["/var/lib/app"].SubPath)
+	assert.Equal(t, "images.fixtures", container.Dirs["/var/lib/app"].SeedFromImage)
+	assert.Equal(t, v1.Quantity("5G"), appSpec.Volumes["app"].Size)
+	assert.Contains(t, appSpec.Volumes, "db")
+
+	// The seed context dir is copied into the image at the mount path
+	assert.Equal(t, "foo", container.Build.BaseImage)
+	assert.Equal(t, map[string]string{"/data": "./fixtures"}, container.Build.ContextDirs)
+
+	_, err = NewAppDefinition([]byte(`
+containers: test: {
+	image: "foo"
+	dirs: "/data": {
+		volume: "db"
+		seedFromImage: "images.missing"
+	}
+}
+`))
+	assert.ErrorContains(t, err, "failed to find image images.missing to seed /data")
+}
+
 func TestDisableProbes(t *testing.T) {
 	appImage, err := NewAppDefinition([]byte(`
 containers: map: probes: {}
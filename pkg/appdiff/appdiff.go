@@ -0,0 +1,142 @@
+package appdiff
+
+import (
+	"encoding/json"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/baaah/pkg/typed"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// Diff returns the changes needed to go from the deployed AppSpec to the new AppSpec. Changes are returned in
+// a stable order so the output can be compared between runs.
+func Diff(oldSpec, newSpec *v1.AppSpec) (result []apiv1.AppSpecChange) {
+	result = append(result, diffMap("containers", "container", oldSpec.Containers, newSpec.Containers, diffContainer)...)
+	result = append(result, diffMap("jobs", "job", oldSpec.Jobs, newSpec.Jobs, diffContainer)...)
+	result = append(result, diffMap("volumes", "volume", oldSpec.Volumes, newSpec.Volumes, diffValue[v1.VolumeRequest])...)
+	// Secrets can have inline data so the values are never included in the output
+	result = append(result, diffMap("secrets", "secret", oldSpec.Secrets, newSpec.Secrets, diffSecret)...)
+	result = append(result, diffMap("routers", "router", oldSpec.Routers, newSpec.Routers, diffValue[v1.Router])...)
+	return result
+}
+
+func diffMap[T any](path, kind string, oldMap, newMap map[string]T, diff func(path, kind string, oldValue, newValue T) []apiv1.AppSpecChange) (result []apiv1.AppSpecChange) {
+	for _, key := range typed.SortedKeys(typed.Concat(keys(oldMap), keys(newMap))) {
+		var (
+			keyPath         = path + "." + key
+			oldValue, inOld = oldMap[key]
+			newValue, inNew = newMap[key]
+		)
+		switch {
+		case !inOld:
+			result = append(result, apiv1.AppSpecChange{Action: apiv1.AppSpecChangeAdded, Kind: kind, Path: keyPath})
+		case !inNew:
+			result = append(result, apiv1.AppSpecChange{Action: apiv1.AppSpecChangeRemoved, Kind: kind, Path: keyPath})
+		default:
+			result = append(result, diff(keyPath, kind, oldValue, newValue)...)
+		}
+	}
+	return result
+}
+
+func keys[T any](data map[string]T) map[string]struct{} {
+	result := map[string]struct{}{}
+	for key := range data {
+		result[key] = struct{}{}
+	}
+	return result
+}
+
+func diffValue[T any](path, kind string, oldValue, newValue T) []apiv1.AppSpecChange {
+	if equality.Semantic.DeepEqual(oldValue, newValue) {
+		return nil
+	}
+	return []apiv1.AppSpecChange{{
+		Action: apiv1.AppSpecChangeChanged,
+		Kind:   kind,
+		Path:   path,
+		Old:    toJSON(oldValue),
+		New:    toJSON(newValue),
+	}}
+}
+
+func diffSecret(path, kind string, oldValue, newValue v1.Secret) []apiv1.AppSpecChange {
+	if equality.Semantic.DeepEqual(oldValue, newValue) {
+		return nil
+	}
+	return []apiv1.AppSpecChange{{
+		Action: apiv1.AppSpecChangeChanged,
+		Kind:   kind,
+		Path:   path,
+	}}
+}
+
+func diffString(path, kind string, oldValue, newValue string) []apiv1.AppSpecChange {
+	if oldValue == newValue {
+		return nil
+	}
+	return []apiv1.AppSpecChange{{
+		Action: apiv1.AppSpecChangeChanged,
+		Kind:   kind,
+		Path:   path,
+		Old:    oldValue,
+		New:    newValue,
+	}}
+}
+
+func diffContainer(path, kind string, oldContainer, newContainer v1.Container) (result []apiv1.AppSpecChange) {
+	result = append(result, diffString(path+".image", "image", oldContainer.Image, newContainer.Image)...)
+	result = append(result, diffMap(path+".env", "env", envMap(oldContainer.Environment), envMap(newContainer.Environment), diffString)...)
+	result = append(result, diffMap(path+".ports", "port", portMap(oldContainer.Ports), portMap(newContainer.Ports), diffString)...)
+	result = append(result, diffMap(path+".dirs", "dir", oldContainer.Dirs, newContainer.Dirs, diffValue[v1.VolumeMount])...)
+	result = append(result, diffValue(path+".permissions", "permissions", oldContainer.Permissions, newContainer.Permissions)...)
+	result = append(result, diffMap(path+".sidecars", "sidecar", oldContainer.Sidecars, newContainer.Sidecars, diffContainer)...)
+
+	// Report any other change to the container as a whole
+	for _, c := range []*v1.Container{&oldContainer, &newContainer} {
+		c.Image = ""
+		c.Environment = nil
+		c.Ports = nil
+		c.Dirs = nil
+		c.Permissions = nil
+		c.Sidecars = nil
+	}
+	if !equality.Semantic.DeepEqual(oldContainer, newContainer) {
+		result = append(result, apiv1.AppSpecChange{
+			Action: apiv1.AppSpecChangeChanged,
+			Kind:   kind,
+			Path:   path,
+		})
+	}
+
+	return result
+}
+
+func envMap(envVars v1.EnvVars) map[string]string {
+	result := map[string]string{}
+	for _, envVar := range envVars {
+		if envVar.Secret.Name != "" {
+			result[envVar.Name] = "secret://" + envVar.Secret.Name + "/" + envVar.Secret.Key
+		} else {
+			result[envVar.Name] = envVar.Value
+		}
+	}
+	return result
+}
+
+func portMap(ports v1.Ports) map[string]string {
+	result := map[string]string{}
+	for _, port := range ports {
+		result[port.String()] = port.String()
+	}
+	return result
+}
+
+func toJSON(obj any) string {
+	data, err := json.Marshal(obj)
+	if err != nil || string(data) == "null" {
+		return ""
+	}
+	return string(data)
+}
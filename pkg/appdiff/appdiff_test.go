@@ -0,0 +1,87 @@
+package appdiff
+
+import (
+	"testing"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	oldSpec := &v1.AppSpec{
+		Containers: map[string]v1.Container{
+			"web": {
+				Image: "sha256:1111",
+				Environment: v1.EnvVars{
+					{Name: "KEEP", Value: "same"},
+					{Name: "CHANGE", Value: "a"},
+					{Name: "REMOVE", Value: "gone"},
+				},
+				Ports: v1.Ports{
+					{Port: 80, TargetPort: 80, Protocol: v1.ProtocolHTTP},
+				},
+			},
+			"old": {},
+		},
+		Secrets: map[string]v1.Secret{
+			"password": {Type: "opaque", Data: map[string]string{"key": "old-value"}},
+		},
+	}
+	newSpec := &v1.AppSpec{
+		Containers: map[string]v1.Container{
+			"web": {
+				Image: "sha256:2222",
+				Environment: v1.EnvVars{
+					{Name: "KEEP", Value: "same"},
+					{Name: "CHANGE", Value: "b"},
+					{Name: "SECRET", Secret: v1.SecretReference{Name: "password", Key: "key"}},
+				},
+				Ports: v1.Ports{
+					{Port: 8080, TargetPort: 8080, Protocol: v1.ProtocolHTTP},
+				},
+				Permissions: &v1.Permissions{
+					Rules: []v1.PolicyRule{{Verbs: []string{"get"}, Resources: []string{"pods"}}},
+				},
+				Scale: &[]int32{2}[0],
+			},
+			"new": {},
+		},
+		Volumes: map[string]v1.VolumeRequest{
+			"data": {},
+		},
+		Secrets: map[string]v1.Secret{
+			"password": {Type: "opaque", Data: map[string]string{"key": "new-value"}},
+		},
+	}
+
+	changes := Diff(oldSpec, newSpec)
+	assert.Equal(t, []apiv1.AppSpecChange{
+		{Action: apiv1.AppSpecChangeAdded, Kind: "container", Path: "containers.new"},
+		{Action: apiv1.AppSpecChangeRemoved, Kind: "container", Path: "containers.old"},
+		{Action: apiv1.AppSpecChangeChanged, Kind: "image", Path: "containers.web.image", Old: "sha256:1111", New: "sha256:2222"},
+		{Action: apiv1.AppSpecChangeChanged, Kind: "env", Path: "containers.web.env.CHANGE", Old: "a", New: "b"},
+		{Action: apiv1.AppSpecChangeRemoved, Kind: "env", Path: "containers.web.env.REMOVE"},
+		{Action: apiv1.AppSpecChangeAdded, Kind: "env", Path: "containers.web.env.SECRET"},
+		{Action: apiv1.AppSpecChangeRemoved, Kind: "port", Path: "containers.web.ports.80/http"},
+		{Action: apiv1.AppSpecChangeAdded, Kind: "port", Path: "containers.web.ports.8080/http"},
+		{Action: apiv1.AppSpecChangeChanged, Kind: "permissions", Path: "containers.web.permissions", New: `{"rules":[{"verbs":["get"],"resources":["pods"]}]}`},
+		{Action: apiv1.AppSpecChangeChanged, Kind: "container", Path: "containers.web"},
+		{Action: apiv1.AppSpecChangeAdded, Kind: "volume", Path: "volumes.data"},
+		{Action: apiv1.AppSpecChangeChanged, Kind: "secret", Path: "secrets.password"},
+	}, changes)
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	spec := &v1.AppSpec{
+		Containers: map[string]v1.Container{
+			"web": {
+				Image: "sha256:1111",
+				Sidecars: map[string]v1.Container{
+					"side": {Image: "sha256:2222"},
+				},
+			},
+		},
+	}
+	assert.Empty(t, Diff(spec, spec.DeepCopy()))
+}
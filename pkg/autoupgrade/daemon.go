@@ -11,6 +11,7 @@ import (
 	"github.com/acorn-io/acorn/pkg/autoupgrade/validate"
 	"github.com/acorn-io/acorn/pkg/config"
 	"github.com/acorn-io/acorn/pkg/images"
+	"github.com/acorn-io/acorn/pkg/labels"
 	tags2 "github.com/acorn-io/acorn/pkg/tags"
 	"github.com/acorn-io/baaah/pkg/router"
 	imagename "github.com/google/go-containerregistry/pkg/name"
@@ -131,8 +132,18 @@ func (d *daemon) sync(ctx context.Context) error {
 	// 2. Add any NEW apps with autoUpgrade turned on to the d.appKeysToNextCheck map with a next check time in the past
 	//    to ensure they'll be checked this sync
 	apps := map[kclient.ObjectKey]v1.AppInstance{}
+	requested := map[kclient.ObjectKey]bool{}
 	for _, app := range appInstances.Items {
 		key := router.Key(app.Namespace, app.Name)
+		if _, ok := app.Annotations[labels.AcornAutoUpgradeCheck]; ok {
+			// An immediate check was requested, usually because a registry reported a push of the app's image
+			delete(app.Annotations, labels.AcornAutoUpgradeCheck)
+			if err := d.client.Update(ctx, &app); err != nil {
+				logrus.Errorf("Problem clearing requested auto-upgrade check of %v: %v", key, err)
+			} else {
+				requested[key] = true
+			}
+		}
 		apps[key] = app
 
 		if _, ok := Mode(app.Spec); ok {
@@ -170,6 +181,14 @@ func (d *daemon) sync(ctx context.Context) error {
 		}
 	}
 
+	// Apps that requested a check are due now, regardless of their interval
+	for k := range requested {
+		if nextCheck, ok := d.appKeysToNextCheck[k]; ok {
+			nextCheck.time = time.Now().Add(-time.Second)
+			d.appKeysToNextCheck[k] = nextCheck
+		}
+	}
+
 	// d.appKeysToNextCheck is now fully up-to-date. This loop iterates over it and compares each app's nextCheck time
 	// to the current time. If it's nextCheck is before Now, then it is time to check the app.
 	// The refresh map is used to group apps by their image. Checking for new versions of an image is relatively expensive
@@ -236,7 +255,7 @@ func (d *daemon) sync(ctx context.Context) error {
 			tagPattern, isPattern := AutoUpgradePattern(app.Spec.Image)
 			if isPattern {
 				var newTag string
-				newTag, err = FindLatest(current.Identifier(), tagPattern, tags)
+				newTag, err = FindLatest(current.Identifier(), tagPattern, withoutSkipped(app, current, tags))
 				if err != nil {
 					logrus.Errorf("Problem finding latest tag for app %v: %v", appKey, err)
 					continue
@@ -294,7 +313,8 @@ func (d *daemon) sync(ctx context.Context) error {
 				if digest == "" && pullErr != nil {
 					logrus.Errorf("Problem getting updated digest for image %v from remote. Error: %v", imageKey.image, pullErr)
 				}
-				if strings.TrimPrefix(app.Status.AppImage.Digest, "sha256:") != strings.TrimPrefix(digest, "sha256:") {
+				if strings.TrimPrefix(app.Status.AppImage.Digest, "sha256:") != strings.TrimPrefix(digest, "sha256:") &&
+					!app.Status.AutoUpgradeRollback.IsSkipped("", digest) {
 					mode, _ := Mode(app.Spec)
 					switch mode {
 					case "enabled":
@@ -362,6 +382,22 @@ func calcNextCheck(defaultNextCheck time.Time, app v1.AppInstance) (time.Time, s
 	return defaultNextCheck, "", nil
 }
 
+// withoutSkipped removes the tags that were rolled back for the app because they never became ready
+func withoutSkipped(app v1.AppInstance, current imagename.Reference, tags []string) []string {
+	if app.Status.AutoUpgradeRollback == nil || len(app.Status.AutoUpgradeRollback.SkippedImages) == 0 {
+		return tags
+	}
+
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		image := strings.TrimPrefix(current.Context().Tag(tag).Name(), defaultNoReg+"/")
+		if !app.Status.AutoUpgradeRollback.IsSkipped(image, "") {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
+
 func removeTagPattern(image string) string {
 	p, ok := AutoUpgradePattern(image)
 	if !ok {
@@ -382,7 +418,33 @@ func AutoUpgradePattern(image string) (string, bool) {
 		tag = parts[len(parts)-1]
 	}
 
-	return tag, strings.ContainsAny(tag, "#*")
+	return tag, strings.ContainsAny(tag, "#*") || IsSemverConstraint(tag)
+}
+
+// MatchesPush returns true if a push of tag to repository could be an upgrade for the app. Only the repository path
+// is compared, not the registry, because registries are often addressed differently by whoever pushes to them.
+func MatchesPush(app v1.AppInstance, repository, tag string) bool {
+	if _, on := Mode(app.Spec); !on || tag == "" {
+		return false
+	}
+
+	ref, err := imagename.ParseReference(removeTagPattern(app.Spec.Image), imagename.WithDefaultRegistry(defaultNoReg))
+	if err != nil || ref.Context().RepositoryStr() != repository {
+		return false
+	}
+
+	if pattern, isPattern := AutoUpgradePattern(app.Spec.Image); isPattern {
+		latest, err := FindLatest("", pattern, []string{tag})
+		return err == nil && latest == tag
+	}
+
+	// Without a pattern, only new content pushed to the app's own tag is an upgrade
+	return ref.Identifier() == tag
+}
+
+// IsSemverConstraint returns true if the tag pattern is a semver range, like "^1.4" or "~1.4.2"
+func IsSemverConstraint(pattern string) bool {
+	return strings.HasPrefix(pattern, "^") || strings.HasPrefix(pattern, "~")
 }
 
 func Mode(appSpec v1.AppInstanceSpec) (string, bool) {
@@ -0,0 +1,54 @@
+package autoupgrade
+
+import (
+	"testing"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	imagename "github.com/google/go-containerregistry/pkg/name"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithoutSkipped(t *testing.T) {
+	app := v1.AppInstance{
+		Status: v1.AppInstanceStatus{
+			AutoUpgradeRollback: &v1.AutoUpgradeRollbackStatus{
+				SkippedImages: []string{"myorg/hello:v2", "sha256:1111", "docker.io/other/hello:v3"},
+			},
+		},
+	}
+
+	current, err := imagename.ParseReference("myorg/hello:v1", imagename.WithDefaultRegistry(defaultNoReg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"v1", "v3"}, withoutSkipped(app, current, []string{"v1", "v2", "v3"}))
+
+	app.Status.AutoUpgradeRollback.SkippedImages = nil
+	assert.Equal(t, []string{"v1", "v2"}, withoutSkipped(app, current, []string{"v1", "v2"}))
+}
+
+func TestMatchesPush(t *testing.T) {
+	appWithImage := func(image string, on bool) v1.AppInstance {
+		return v1.AppInstance{
+			Spec: v1.AppInstanceSpec{
+				Image:       image,
+				AutoUpgrade: &on,
+			},
+		}
+	}
+
+	// Patterns match tags that satisfy them
+	assert.True(t, MatchesPush(appWithImage("localhost:5000/myorg/hello:v#.#", false), "myorg/hello", "v1.2"))
+	assert.False(t, MatchesPush(appWithImage("localhost:5000/myorg/hello:v#.#", false), "myorg/hello", "v1.2.3"))
+	assert.True(t, MatchesPush(appWithImage("myorg/hello:^1.4", false), "myorg/hello", "1.5.0"))
+	assert.False(t, MatchesPush(appWithImage("myorg/hello:^1.4", false), "myorg/hello", "2.0.0"))
+
+	// Without a pattern only the app's own tag matches
+	assert.True(t, MatchesPush(appWithImage("myorg/hello", true), "myorg/hello", "latest"))
+	assert.False(t, MatchesPush(appWithImage("myorg/hello:latest", true), "myorg/hello", "v2"))
+
+	// Other repositories, apps without auto-upgrade, and pushes without a tag don't match
+	assert.False(t, MatchesPush(appWithImage("myorg/hello:v#.#", false), "myorg/other", "v1.2"))
+	assert.False(t, MatchesPush(appWithImage("myorg/hello:latest", false), "myorg/hello", "latest"))
+	assert.False(t, MatchesPush(appWithImage("myorg/hello:v#.#", false), "myorg/hello", ""))
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/acorn-io/acorn/pkg/autoupgrade/validate"
 	"k8s.io/utils/strings/slices"
 )
 
@@ -22,7 +24,13 @@ import (
 // - "v#.#" - Matches: "v1.0", "v2.0" (return as latest). Doesn't match: "v1.alpha", "1.0", "v1.0.0"
 // - "v1.0-*" - Matches: "v1.0-alpha", "v1.0-beta" (returned as latest). Doesn't match: "v1.0"
 // - "v1.#-**" - Matches: "v1.0-cv23jkha", "v1.1-2020-01-01" (returned as latest).
+//
+// A pattern starting with ^ or ~ is instead a semver range, see findLatestSemver.
 func FindLatest(current, pattern string, tags []string) (string, error) {
+	if IsSemverConstraint(pattern) {
+		return findLatestSemver(current, pattern, tags)
+	}
+
 	pattern = "^" + pattern + "$"
 
 	// ** denotes a part of the tag that should be completely ignored for both matching and sorting. Replace it with
@@ -118,6 +126,38 @@ func FindLatest(current, pattern string, tags []string) (string, error) {
 	return latest, nil
 }
 
+// findLatestSemver returns the highest version from the tags slice that satisfies the semver range pattern. Tags that
+// are not versions are ignored, a leading "v" is allowed. Some examples:
+// - "^1.4" - Matches: "1.4.0", "v1.9.2" (returned as latest). Doesn't match: "2.0.0", "1.5.0-rc.1"
+// - "~1.4.2" - Matches: "1.4.2", "1.4.10" (returned as latest). Doesn't match: "1.5.0"
+// - "^1.4-0" - Matches: "1.4.0", "1.5.0-rc.1" (returned as latest). Doesn't match: "2.0.0-rc.1"
+func findLatestSemver(current, pattern string, tags []string) (string, error) {
+	constraint, err := validate.SemverConstraint(pattern)
+	if err != nil {
+		return "", err
+	}
+
+	latest := current
+	latestVersion, err := semver.NewVersion(current)
+	if err != nil || !constraint.Check(latestVersion) {
+		// The current tag doesn't satisfy the range, so any tag that does is "later" than it
+		latestVersion = nil
+	}
+
+	for _, tag := range tags {
+		version, err := semver.NewVersion(tag)
+		if err != nil || !constraint.Check(version) {
+			continue
+		}
+		if latestVersion == nil || version.GreaterThan(latestVersion) {
+			latest = tag
+			latestVersion = version
+		}
+	}
+
+	return latest, nil
+}
+
 // We need to know two things about a matching group: it's name and whether it should be sorted alphabetically or
 // numerically. pType will be either "alpha" or "numeric"
 type namedMatchingGroup struct {
@@ -35,6 +35,42 @@ func TestTags(t *testing.T) {
 	test(t, "*", 2, []string{"v1.0-alpha.100", "v1.0-beta", "v1.0-zeta"})
 }
 
+func TestSemverTags(t *testing.T) {
+	tags := []string{"1.4.0", "v1.4.3", "1.5.0-rc.1", "1.9.2", "2.0.0", "latest"}
+
+	// Latest 1.x, but not 2.0 and no prereleases
+	test(t, "^1.4", 3, tags)
+
+	// Patch releases of 1.4 only
+	test(t, "~1.4.2", 1, tags)
+
+	// Prereleases are included if the range has a prerelease
+	test(t, "^1.4-0", 3, tags)
+	test(t, "~1.4-0", 2, []string{"1.4.0", "1.4.1-rc.1", "1.4.1-rc.2", "1.5.0-rc.1"})
+
+	// Current is kept if nothing newer satisfies the range
+	latest, err := FindLatest("1.9.3", "^1.4", tags)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.9.3", latest)
+
+	// An invalid range is an error
+	_, err = FindLatest("", "^one", tags)
+	assert.Error(t, err)
+}
+
+func TestAutoUpgradePattern(t *testing.T) {
+	for image, isPattern := range map[string]bool{
+		"myorg/app:v#.#":     true,
+		"myorg/app:^1.4":     true,
+		"myorg/app:~1.4.2":   true,
+		"myorg/app:1.4":      false,
+		"localhost:5000/app": false,
+	} {
+		_, ok := AutoUpgradePattern(image)
+		assert.Equal(t, isPattern, ok, image)
+	}
+}
+
 func test(t *testing.T, pattern string, expectedIndex int, tags []string) {
 	t.Helper()
 	latest, err := FindLatest("", pattern, tags)
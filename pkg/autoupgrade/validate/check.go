@@ -2,7 +2,11 @@ package validate
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/robfig/cron/v3"
 )
 
 // AutoUpgradeInterval checks that the supplied val can be parsed as a duration and is greater than the system minimum (15 seconds).
@@ -18,3 +22,76 @@ func AutoUpgradeInterval(val string) (time.Duration, error) {
 
 	return newDur, nil
 }
+
+// AutoUpgradeRollbackAfter checks that the supplied val can be parsed as a positive duration.
+func AutoUpgradeRollbackAfter(val string) (time.Duration, error) {
+	dur, err := time.ParseDuration(val)
+	if err != nil {
+		return 0, fmt.Errorf("auto-upgrade-rollback-after's value \"%v\" is invalid. Must be an interval with a time unit like  \"10m\"", val)
+	}
+
+	if dur <= 0 {
+		return 0, fmt.Errorf("auto-upgrade-rollback-after %v is invalid. Must be greater than zero", val)
+	}
+
+	return dur, nil
+}
+
+// SemverConstraint checks that the supplied val is a semver range for auto-upgrade, like "^1.4" or "~1.4.2". Prereleases
+// only satisfy the range if it includes a prerelease itself, like "^1.4-0".
+func SemverConstraint(val string) (*semver.Constraints, error) {
+	if !strings.HasPrefix(val, "^") && !strings.HasPrefix(val, "~") {
+		return nil, fmt.Errorf("semver range \"%v\" is invalid. Must start with ^ or ~ like \"^1.4\"", val)
+	}
+
+	constraint, err := semver.NewConstraint(val)
+	if err != nil {
+		return nil, fmt.Errorf("semver range \"%v\" is invalid: %w", val, err)
+	}
+
+	return constraint, nil
+}
+
+// Window is a recurring period of time in which automatic upgrades are allowed
+type Window struct {
+	schedule cron.Schedule
+	duration time.Duration
+}
+
+// IsOpen returns true if t is inside the window
+func (w *Window) IsOpen(t time.Time) bool {
+	return !w.schedule.Next(t.Add(-w.duration)).After(t)
+}
+
+// Next returns when the window opens next, or t if the window is open at t
+func (w *Window) Next(t time.Time) time.Time {
+	if w.IsOpen(t) {
+		return t
+	}
+	return w.schedule.Next(t)
+}
+
+// MaintenanceWindow checks that the supplied val is a cron schedule followed by a duration, for example "0 2 * * 6 4h"
+// is open every Saturday from 02:00 for four hours. A time zone can be set with a CRON_TZ= prefix.
+func MaintenanceWindow(val string) (*Window, error) {
+	val = strings.TrimSpace(val)
+	i := strings.LastIndexAny(val, " \t")
+	if i < 0 {
+		return nil, fmt.Errorf("maintenance window \"%v\" is invalid. Must be a cron schedule followed by a duration like \"0 2 * * 6 4h\"", val)
+	}
+
+	duration, err := time.ParseDuration(val[i+1:])
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("maintenance window \"%v\" is invalid. Must end with a duration with a time unit like \"4h\"", val)
+	}
+
+	schedule, err := cron.ParseStandard(strings.TrimSpace(val[:i]))
+	if err != nil {
+		return nil, fmt.Errorf("maintenance window \"%v\" has an invalid cron schedule: %w", val, err)
+	}
+
+	return &Window{
+		schedule: schedule,
+		duration: duration,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	w, err := MaintenanceWindow("0 2 * * 6 4h")
+	require.NoError(t, err)
+
+	// Saturday 2022-12-17 is inside the window from 02:00 to 06:00
+	saturday := time.Date(2022, 12, 17, 3, 30, 0, 0, time.UTC)
+	assert.True(t, w.IsOpen(saturday))
+	assert.Equal(t, saturday, w.Next(saturday))
+	assert.True(t, w.IsOpen(time.Date(2022, 12, 17, 2, 0, 0, 0, time.UTC)))
+	assert.False(t, w.IsOpen(time.Date(2022, 12, 17, 6, 0, 0, 0, time.UTC)))
+
+	monday := time.Date(2022, 12, 19, 12, 0, 0, 0, time.UTC)
+	assert.False(t, w.IsOpen(monday))
+	assert.Equal(t, time.Date(2022, 12, 24, 2, 0, 0, 0, time.UTC), w.Next(monday))
+
+	w, err = MaintenanceWindow("@daily 30m")
+	require.NoError(t, err)
+	assert.True(t, w.IsOpen(time.Date(2022, 12, 19, 0, 10, 0, 0, time.UTC)))
+	assert.False(t, w.IsOpen(time.Date(2022, 12, 19, 0, 30, 0, 0, time.UTC)))
+
+	for _, invalid := range []string{"", "4h", "0 2 * * 6", "0 2 * * 6 0s", "0 25 * * * 1h", "every day 1h"} {
+		_, err := MaintenanceWindow(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSemverConstraint(t *testing.T) {
+	for _, valid := range []string{"^1.4", "~1.4.2", "^v1", "^1.4-0"} {
+		_, err := SemverConstraint(valid)
+		assert.NoError(t, err, valid)
+	}
+
+	for _, invalid := range []string{"", "1.4", ">=1.4", "^one", "~1.4.x.y"} {
+		_, err := SemverConstraint(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,166 @@
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/autoupgrade"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/rancher/wrangler/pkg/merr"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Path is where the api-server serves the registry webhook
+const Path = "/registry-webhook"
+
+// maxBodySize limits the size of a notification. The registry sends events in small batches, so this is plenty.
+const maxBodySize = 1 << 20
+
+// Envelope is the body of a Docker Registry v2 / OCI distribution notification. Only the fields needed to find the
+// apps to upgrade are decoded.
+type Envelope struct {
+	Events []Event `json:"events,omitempty"`
+}
+
+type Event struct {
+	Action string `json:"action,omitempty"`
+	Target Target `json:"target,omitempty"`
+}
+
+type Target struct {
+	Repository string `json:"repository,omitempty"`
+	Tag        string `json:"tag,omitempty"`
+}
+
+type push struct {
+	repository string
+	tag        string
+}
+
+type Handler struct {
+	client kclient.Client
+}
+
+// NewHandler returns a handler for registry notifications that wakes the auto-upgrade daemon for the apps whose image
+// was pushed to. Requests must have a bearer token that matches the token key of the acorn-registry-webhook secret in
+// the acorn-system namespace.
+func NewHandler(client kclient.Client) *Handler {
+	return &Handler{
+		client: client,
+	}
+}
+
+func (h *Handler) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(rw, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if ok, err := h.authenticate(req); err != nil {
+		logrus.Errorf("Problem authenticating registry webhook request: %v", err)
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	} else if !ok {
+		http.Error(rw, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var envelope Envelope
+	if err := json.NewDecoder(http.MaxBytesReader(rw, req.Body, maxBodySize)).Decode(&envelope); err != nil {
+		http.Error(rw, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Errors are returned to the registry, which will retry the notification
+	if err := h.requestChecks(req.Context(), pushes(envelope)); err != nil {
+		logrus.Errorf("Problem handling registry webhook: %v", err)
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	rw.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) authenticate(req *http.Request) (bool, error) {
+	auth := req.Header.Get("Authorization")
+	token := strings.TrimPrefix(auth, "Bearer ")
+	if token == auth || token == "" {
+		return false, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := h.client.Get(req.Context(), router.Key(system.Namespace, system.RegistryWebhookName), secret); apierrors.IsNotFound(err) {
+		// The webhook is disabled until a token is configured
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	expected := secret.Data["token"]
+	return len(expected) > 0 && subtle.ConstantTimeCompare(expected, []byte(token)) == 1, nil
+}
+
+// pushes returns the tags that were pushed. Other events, and pushes without a tag like blobs or the manifests of
+// a multi-arch image, are ignored.
+func pushes(envelope Envelope) []push {
+	var result []push
+	for _, event := range envelope.Events {
+		if event.Action == "push" && event.Target.Tag != "" {
+			result = append(result, push{
+				repository: event.Target.Repository,
+				tag:        event.Target.Tag,
+			})
+		}
+	}
+	return result
+}
+
+// requestChecks marks every app that one of the pushes could be an upgrade for, so the auto-upgrade daemon in the
+// controller checks it immediately instead of waiting for its next interval. An app that can't be marked doesn't stop
+// the others from being marked, the errors are returned together.
+func (h *Handler) requestChecks(ctx context.Context, pushes []push) error {
+	if len(pushes) == 0 {
+		return nil
+	}
+
+	var apps v1.AppInstanceList
+	if err := h.client.List(ctx, &apps); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, app := range apps.Items {
+		for _, p := range pushes {
+			if !autoupgrade.MatchesPush(app, p.repository, p.tag) {
+				continue
+			}
+
+			// Only the annotation is patched, so a concurrent change to the app doesn't make this fail
+			patch := kclient.MergeFrom(app.DeepCopy())
+			if app.Annotations == nil {
+				app.Annotations = map[string]string{}
+			}
+			app.Annotations[labels.AcornAutoUpgradeCheck] = time.Now().UTC().Format(time.RFC3339)
+			if err := h.client.Patch(ctx, &app, patch); err != nil {
+				errs = append(errs, fmt.Errorf("requesting an auto-upgrade check of app %s/%s: %w", app.Namespace, app.Name, err))
+				break
+			}
+
+			logrus.Infof("Requesting an auto-upgrade check of app %s/%s because %s:%s was pushed", app.Namespace, app.Name, p.repository, p.tag)
+			break
+		}
+	}
+
+	return merr.NewErrors(errs...)
+}
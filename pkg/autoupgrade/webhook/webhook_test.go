@@ -0,0 +1,159 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// notification is what a registry:2 container sends when v1.3 of myorg/hello is pushed to it
+const notification = `{
+  "events": [
+    {
+      "id": "320678d8-ca14-430f-8bb6-4ca139cd83f7",
+      "timestamp": "2022-12-19T12:00:00.000000000Z",
+      "action": "push",
+      "target": {
+        "mediaType": "application/octet-stream",
+        "digest": "sha256:fea8895f450959fa676bcc1df0611ea93823a735a01205fd8622846041d0c7cf",
+        "repository": "myorg/hello"
+      }
+    },
+    {
+      "id": "6d1a4e0f-6fd0-4b2d-9d2b-0a9cc1f5c31b",
+      "timestamp": "2022-12-19T12:00:00.000000000Z",
+      "action": "push",
+      "target": {
+        "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+        "digest": "sha256:b50b4b5d4e4e9dcf1d6b5b2a7c4b1f0bbd7ef0a6d1e3df9d4b3b4b1aa6ef0e3a",
+        "repository": "myorg/hello",
+        "url": "http://localhost:5000/v2/myorg/hello/manifests/sha256:b50b4b5d4e4e9dcf1d6b5b2a7c4b1f0bbd7ef0a6d1e3df9d4b3b4b1aa6ef0e3a",
+        "tag": "v1.3"
+      },
+      "request": {
+        "host": "localhost:5000",
+        "method": "PUT"
+      }
+    }
+  ]
+}`
+
+func testApp(name, image string) *v1.AppInstance {
+	return &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "acorn",
+		},
+		Spec: v1.AppInstanceSpec{
+			Image: image,
+		},
+	}
+}
+
+// failingClient fails to update apps, and to patch the apps with the given names
+type failingClient struct {
+	kclient.Client
+	names map[string]bool
+}
+
+func (f failingClient) Update(context.Context, kclient.Object, ...kclient.UpdateOption) error {
+	return fmt.Errorf("apps must be patched")
+}
+
+func (f failingClient) Patch(ctx context.Context, obj kclient.Object, patch kclient.Patch, opts ...kclient.PatchOption) error {
+	if f.names[obj.GetName()] {
+		return fmt.Errorf("patch failed")
+	}
+	return f.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func serve(t *testing.T, token, body string, failing ...string) (*httptest.ResponseRecorder, map[string]bool) {
+	t.Helper()
+
+	var c kclient.Client = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      system.RegistryWebhookName,
+				Namespace: system.Namespace,
+			},
+			Data: map[string][]byte{
+				"token": []byte("secret-token"),
+			},
+		},
+		testApp("matching", "localhost:5000/myorg/hello:v#.#"),
+		testApp("other-pattern", "localhost:5000/myorg/hello:v1.#-rc"),
+		testApp("other-repo", "localhost:5000/myorg/other:v#.#"),
+		testApp("matching-too", "localhost:5000/myorg/hello:v1.#"),
+	).Build()
+	c = failingClient{
+		Client: c,
+		names:  map[string]bool{},
+	}
+	for _, name := range failing {
+		c.(failingClient).names[name] = true
+	}
+
+	req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/vnd.docker.distribution.events.v1+json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rw := httptest.NewRecorder()
+	NewHandler(c).ServeHTTP(rw, req)
+
+	var apps v1.AppInstanceList
+	if err := c.List(req.Context(), &apps); err != nil {
+		t.Fatal(err)
+	}
+	requested := map[string]bool{}
+	for _, app := range apps.Items {
+		_, requested[app.Name] = app.Annotations[labels.AcornAutoUpgradeCheck]
+	}
+	return rw, requested
+}
+
+func TestRegistryWebhook(t *testing.T) {
+	rw, requested := serve(t, "secret-token", notification)
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, map[string]bool{
+		"matching":      true,
+		"matching-too":  true,
+		"other-pattern": false,
+		"other-repo":    false,
+	}, requested)
+}
+
+func TestRegistryWebhookPatchFails(t *testing.T) {
+	rw, requested := serve(t, "secret-token", notification, "matching")
+	assert.Equal(t, http.StatusInternalServerError, rw.Code)
+	assert.Contains(t, rw.Body.String(), "requesting an auto-upgrade check of app acorn/matching: patch failed")
+	// The other apps are still marked
+	assert.False(t, requested["matching"])
+	assert.True(t, requested["matching-too"])
+}
+
+func TestRegistryWebhookUnauthorized(t *testing.T) {
+	for _, token := range []string{"", "wrong-token"} {
+		rw, requested := serve(t, token, notification)
+		assert.Equal(t, http.StatusUnauthorized, rw.Code)
+		assert.False(t, requested["matching"])
+	}
+}
+
+func TestRegistryWebhookInvalidBody(t *testing.T) {
+	rw, _ := serve(t, "secret-token", "not json")
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+}
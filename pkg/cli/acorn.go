@@ -38,7 +38,9 @@ func New() *cobra.Command {
 		NewController(cmdContext),
 		NewCredential(cmdContext),
 		NewRender(cmdContext),
+		NewDiff(cmdContext),
 		NewExec(cmdContext),
+		NewHistory(cmdContext),
 		NewImage(cmdContext),
 		NewInstall(cmdContext),
 		NewUninstall(cmdContext),
@@ -49,6 +51,7 @@ func New() *cobra.Command {
 		NewPull(cmdContext),
 		NewPush(cmdContext),
 		NewRm(cmdContext),
+		NewRollback(cmdContext),
 		NewRun(cmdContext),
 		NewUpdate(cmdContext),
 		NewSecret(cmdContext),
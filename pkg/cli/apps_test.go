@@ -48,7 +48,7 @@ func TestApp(t *testing.T) {
 				client: &testdata.MockClient{},
 			},
 			wantErr: false,
-			wantOut: "NAME      IMAGE     HEALTHY   UP-TO-DATE   CREATED    ENDPOINTS   MESSAGE\nfound                                      292y ago               \n",
+			wantOut: "NAME      IMAGE     HEALTHY   UP-TO-DATE   REPLICAS   CREATED    ENDPOINTS   MESSAGE\nfound                                                 292y ago               \n",
 		},
 		{
 			name: "acorn app found", fields: fields{
@@ -67,7 +67,26 @@ func TestApp(t *testing.T) {
 				client: &testdata.MockClient{},
 			},
 			wantErr: false,
-			wantOut: "NAME      IMAGE     HEALTHY   UP-TO-DATE   CREATED    ENDPOINTS   MESSAGE\nfound                                      292y ago               \n",
+			wantOut: "NAME      IMAGE     HEALTHY   UP-TO-DATE   REPLICAS   CREATED    ENDPOINTS   MESSAGE\nfound                                                 292y ago               \n",
+		},
+		{
+			name: "acorn app scaling", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"scaling"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "NAME      IMAGE     HEALTHY   UP-TO-DATE   REPLICAS   CREATED    ENDPOINTS   MESSAGE\nscaling             2/5       2            2/5        292y ago               \n",
 		},
 		{
 			name: "acorn app dne", fields: fields{
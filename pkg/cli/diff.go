@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/acorn-io/acorn/pkg/autoupgrade"
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/cli/builder/table"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/deployargs"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/acorn-io/acorn/pkg/tables"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func NewDiff(c client.CommandContext) *cobra.Command {
+	cmd := cli.Command(&Diff{client: c.ClientFactory}, cobra.Command{
+		Use: "diff [flags] APP_NAME [deploy flags]",
+		Example: `
+# Show the changes updating my-app to a new image would make
+acorn diff --image ghcr.io/acorn-io/library/hello-world:v2 my-app
+
+# Show the changes new args would make, as JSON for use in CI
+acorn diff -o json my-app --replicas 3`,
+		SilenceUsage: true,
+		Short:        "Show the changes an update would make to an app",
+		Args:         cobra.MinimumNArgs(1),
+	})
+	cmd.Flags().SetInterspersed(false)
+	return cmd
+}
+
+type Diff struct {
+	Image   string   `usage:"The image to compare against (default: the current image of the app)"`
+	Profile []string `usage:"Profile to assign default values"`
+	Output  string   `usage:"Output format (json, yaml, {{gotemplate}})" short:"o"`
+	client  client.ClientFactory
+}
+
+func (s *Diff) Run(cmd *cobra.Command, args []string) error {
+	c, err := s.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	opts := client.AppUpdateOptions{
+		Image:    s.Image,
+		Profiles: s.Profile,
+	}
+
+	if len(args) > 1 {
+		imageForFlags := s.Image
+		if imageForFlags == "" {
+			app, err := c.AppGet(cmd.Context(), name)
+			if err != nil {
+				return err
+			}
+			imageForFlags = app.Spec.Image
+			if _, isPattern := autoupgrade.AutoUpgradePattern(imageForFlags); isPattern {
+				imageForFlags = app.Status.AppImage.ID
+			}
+		}
+
+		_, flags, err := deployargs.ToFlagsFromImage(cmd.Context(), c, imageForFlags)
+		if err != nil {
+			return err
+		}
+
+		opts.DeployArgs, err = flags.Parse(args)
+		if pflag.ErrHelp == err {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+
+	diff, err := c.AppDiff(cmd.Context(), name, &opts)
+	if err != nil {
+		return err
+	}
+
+	out := table.NewWriter(tables.AppSpecChange, system.UserNamespace(), false, s.Output)
+	if s.Output != "" {
+		out.Write(diff)
+		return out.Err()
+	}
+
+	if len(diff.Changes) == 0 {
+		fmt.Println("No changes")
+		return nil
+	}
+
+	for _, change := range diff.Changes {
+		out.Write(change)
+	}
+
+	return out.Err()
+}
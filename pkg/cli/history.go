@@ -0,0 +1,46 @@
+package cli
+
+import (
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/cli/builder/table"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/acorn-io/acorn/pkg/tables"
+	"github.com/spf13/cobra"
+)
+
+func NewHistory(c client.CommandContext) *cobra.Command {
+	return cli.Command(&History{client: c.ClientFactory}, cobra.Command{
+		Use: "history [flags] APP_NAME",
+		Example: `
+acorn history my-app`,
+		SilenceUsage: true,
+		Short:        "List the revisions of an app",
+		Args:         cobra.ExactArgs(1),
+	})
+}
+
+type History struct {
+	Output string `usage:"Output format (json, yaml, {{gotemplate}})" short:"o"`
+	client client.ClientFactory
+}
+
+func (a *History) Run(cmd *cobra.Command, args []string) error {
+	client, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	out := table.NewWriter(tables.AppRevision, system.UserNamespace(), false, a.Output)
+
+	revisions, err := client.AppHistory(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	for _, revision := range revisions {
+		out.Write(revision)
+	}
+
+	return out.Err()
+}
@@ -30,6 +30,8 @@ type Install struct {
 	APIServerReplicas  *int `usage:"acorn-api deployment replica count" name:"api-server-replicas"`
 	ControllerReplicas *int `usage:"acorn-controller deployment replica count"`
 
+	RegistryWebhookPort *int `usage:"Also serve the registry webhook over plain HTTP on this port of the acorn-api service, for registries that can't verify its certificate (default: disabled)"`
+
 	apiv1.Config
 	client client.ClientFactory
 }
@@ -41,10 +43,11 @@ func (i *Install) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	return install.Install(cmd.Context(), image, &install.Options{
-		SkipChecks:         i.SkipChecks,
-		OutputFormat:       i.Output,
-		Config:             i.Config,
-		APIServerReplicas:  i.APIServerReplicas,
-		ControllerReplicas: i.ControllerReplicas,
+		SkipChecks:          i.SkipChecks,
+		OutputFormat:        i.Output,
+		Config:              i.Config,
+		APIServerReplicas:   i.APIServerReplicas,
+		ControllerReplicas:  i.ControllerReplicas,
+		RegistryWebhookPort: i.RegistryWebhookPort,
 	})
 }
@@ -2,10 +2,16 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/acorn-io/acorn/pkg/appdefinition"
 	"github.com/acorn-io/acorn/pkg/client"
 
 	cli "github.com/acorn-io/acorn/pkg/cli/builder"
 	"github.com/acorn-io/acorn/pkg/deployargs"
+	"github.com/acorn-io/acorn/pkg/manifests"
+	"github.com/acorn-io/acorn/pkg/system"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -22,10 +28,13 @@ func NewRender(c client.CommandContext) *cobra.Command {
 }
 
 type Render struct {
-	File    string   `short:"f" usage:"Name of the dev file" default:"DIRECTORY/Acornfile"`
-	Profile []string `usage:"Profile to assign default values"`
-	Output  string   `usage:"Output in JSON or YAML" default:"json" short:"o"`
-	client  client.ClientFactory
+	File      string   `short:"f" usage:"Name of the dev file" default:"DIRECTORY/Acornfile"`
+	Profile   []string `usage:"Profile to assign default values"`
+	Output    string   `usage:"Output in JSON or YAML" default:"json" short:"o"`
+	Manifests bool     `usage:"Render the Kubernetes manifests of the app as YAML instead of the Acornfile"`
+	Name      string   `usage:"Name of the app when rendering manifests (default: name of DIRECTORY)"`
+	Image     string   `usage:"Image reference of the app when rendering manifests (default: name of the app)"`
+	client    client.ClientFactory
 }
 
 func (s *Render) Run(cmd *cobra.Command, args []string) error {
@@ -51,6 +60,10 @@ func (s *Render) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if s.Manifests {
+		return s.renderManifests(cmd, cwd, appDef)
+	}
+
 	var v string
 	switch s.Output {
 	case "yaml":
@@ -69,3 +82,26 @@ func (s *Render) Run(cmd *cobra.Command, args []string) error {
 	fmt.Print(v)
 	return nil
 }
+
+func (s *Render) renderManifests(cmd *cobra.Command, cwd string, appDef *appdefinition.AppDefinition) error {
+	appName := s.Name
+	if appName == "" {
+		abs, err := filepath.Abs(cwd)
+		if err != nil {
+			return err
+		}
+		appName = filepath.Base(abs)
+	}
+
+	objs, err := manifests.FromAppDefinition(cmd.Context(), appDef, manifests.Options{
+		Name:      appName,
+		Namespace: system.UserNamespace(),
+		Image:     s.Image,
+		Profiles:  s.Profile,
+	})
+	if err != nil {
+		return err
+	}
+
+	return manifests.Write(os.Stdout, objs)
+}
@@ -69,6 +69,25 @@ func TestRender(t *testing.T) {
 			wantErr: false,
 			wantOut: "./testdata/render/render_test.txt",
 		},
+		{
+			name: "acorn render --manifests .", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"--manifests", "./testdata/render/"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "./testdata/render/render_manifests_test.txt",
+		},
 	}
 	for _, tt := range tests {
 		r, w, _ := os.Pipe()
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewRollback(c client.CommandContext) *cobra.Command {
+	return cli.Command(&Rollback{client: c.ClientFactory}, cobra.Command{
+		Use: "rollback [flags] APP_NAME [REVISION]",
+		Example: `
+# Roll back to the previous revision
+acorn rollback my-app
+
+# Roll back to revision 3, as listed by "acorn history my-app"
+acorn rollback my-app 3`,
+		SilenceUsage: true,
+		Short:        "Roll back an app to a previous revision",
+		Args:         cobra.RangeArgs(1, 2),
+	})
+}
+
+type Rollback struct {
+	client client.ClientFactory
+}
+
+func (a *Rollback) Run(cmd *cobra.Command, args []string) error {
+	client, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	var revision int64
+	if len(args) > 1 {
+		revision, err = strconv.ParseInt(args[1], 10, 64)
+		if err != nil || revision < 1 {
+			return fmt.Errorf("invalid revision [%s], must be a positive number", args[1])
+		}
+	}
+
+	app, err := client.AppRollback(cmd.Context(), args[0], revision)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(app.Name)
+	return nil
+}
@@ -63,8 +63,22 @@ func NewRun(c client.CommandContext) *cobra.Command {
   # Bind the acorn volume named "mydata" into the current app, replacing the volume named "data", See "acorn volumes --help for more info"
   acorn run --volume mydata:data .
 
+# Compute Resources Syntax
+  # Set the memory request and limit of all containers to 512Mi
+  acorn run --memory 512Mi .
+
+  # Set the memory request and limit of the container "web" to 1Gi and its CPU to 2 cores
+  acorn run --memory web=1Gi --cpu web=2 .
+
+# Node Placement Syntax
+  # Schedule all containers and jobs on nodes with the label "pool=gpu"
+  acorn run --node-selector pool=gpu .
+
+  # Schedule the container "web" on arm64 nodes
+  acorn run --node-selector web:kubernetes.io/arch=arm64 .
+
 # Automatic upgrades
-  # Automatic upgrade for an app will be enabled if '#', '*', or '**' appears in the image's tag. Tags will sorted according to the rules for these special characters described below. The newest tag will be selected for upgrade.
+  # Automatic upgrade for an app will be enabled if '#', '*', or '**' appears in the image's tag or the tag is a semver range. Tags will sorted according to the rules for these special characters described below. The newest tag will be selected for upgrade.
 
   # '#' denotes a segment of the image tag that should be sorted numerically when finding the newest tag.
   # This example deploys the hello-world app with auto-upgrade enabled and matching all major, minor, and patch versions:
@@ -78,6 +92,10 @@ func NewRun(c client.CommandContext) *cobra.Command {
   # This example would sort numerically according to major and minor version (ie v1.2) and ignore anything following the "-":
   acorn run myorg/hello-world:v#.#-**
 
+  # A tag starting with '^' or '~' is a semver range. The highest version satisfying it will be selected for upgrade.
+  # This example upgrades to any 1.x release from 1.4 on, but not to 2.0 or to prereleases. Use "^1.4-0" to include prereleases:
+  acorn run "myorg/hello-world:^1.4"
+
   # Automatic upgrades can be configured explicitly via a flag.
   # In this example, the tag will always be "latest", but acorn will periodically check to see if new content has been pushed to that tag:
   acorn run --auto-upgrade myorg/hello-world:latest
@@ -86,6 +104,12 @@ func NewRun(c client.CommandContext) *cobra.Command {
   acorn run --notify-upgrade myorg/hello-world:v#.#.# myapp
   # To proceed with an upgrade you've been notified of:
   acorn update --confirm-upgrade myapp
+
+  # To roll back an upgrade that isn't ready within 10 minutes and skip the image it upgraded to:
+  acorn run --auto-upgrade-rollback-after 10m myorg/hello-world:v#.#.# myapp
+
+  # To only apply upgrades on Saturdays between 02:00 and 06:00:
+  acorn run --maintenance-window "0 2 * * 6 4h" myorg/hello-world:v#.#.# myapp
 `})
 	cmd.PersistentFlags().Lookup("dangerous").Hidden = true
 	cmd.Flags().SetInterspersed(false)
@@ -105,24 +129,29 @@ type Run struct {
 }
 
 type RunArgs struct {
-	Name            string   `usage:"Name of app to create" short:"n"`
-	File            string   `short:"f" usage:"Name of the build file" default:"DIRECTORY/Acornfile"`
-	Volume          []string `usage:"Bind an existing volume (format existing:vol-name,field=value) (ex: pvc-name:app-data)" short:"v" split:"false"`
-	Secret          []string `usage:"Bind an existing secret (format existing:sec-name) (ex: sec-name:app-secret)" short:"s"`
-	Link            []string `usage:"Link external app as a service in the current app (format app-name:container-name)"`
-	PublishAll      *bool    `usage:"Publish all (true) or none (false) of the defined ports of application" short:"P"`
-	Publish         []string `usage:"Publish port of application (format [public:]private) (ex 81:80)" short:"p"`
-	Expose          []string `usage:"In cluster expose ports of an application (format [public:]private) (ex 81:80)"`
-	Profile         []string `usage:"Profile to assign default values"`
-	Env             []string `usage:"Environment variables to set on running containers" short:"e"`
-	Label           []string `usage:"Add labels to the app and the resources it creates (format [type:][name:]key=value) (ex k=v, containers:k=v)" short:"l"`
-	Annotation      []string `usage:"Add annotations to the app and the resources it creates (format [type:][name:]key=value) (ex k=v, containers:k=v)"`
-	Dangerous       bool     `usage:"Automatically approve all privileges requested by the application"`
-	Output          string   `usage:"Output API request without creating app (json, yaml)" short:"o"`
-	TargetNamespace string   `usage:"The name of the namespace to be created and deleted for the application resources"`
-	NotifyUpgrade   *bool    `usage:"If true and the app is configured for auto-upgrades, you will be notified in the CLI when an upgrade is available and must confirm it"`
-	AutoUpgrade     *bool    `usage:"Enabled automatic upgrades."`
-	Interval        string   `usage:"If configured for auto-upgrade, this is the time interval at which to check for new releases (ex: 1h, 5m)"`
+	Name              string   `usage:"Name of app to create" short:"n"`
+	File              string   `short:"f" usage:"Name of the build file" default:"DIRECTORY/Acornfile"`
+	Volume            []string `usage:"Bind an existing volume (format existing:vol-name,field=value) (ex: pvc-name:app-data)" short:"v" split:"false"`
+	Secret            []string `usage:"Bind an existing secret (format existing:sec-name) (ex: sec-name:app-secret)" short:"s"`
+	Link              []string `usage:"Link external app as a service in the current app (format app-name:container-name)"`
+	PublishAll        *bool    `usage:"Publish all (true) or none (false) of the defined ports of application" short:"P"`
+	Publish           []string `usage:"Publish port of application (format [public:]private) (ex 81:80)" short:"p"`
+	Expose            []string `usage:"In cluster expose ports of an application (format [public:]private) (ex 81:80)"`
+	Profile           []string `usage:"Profile to assign default values"`
+	Env               []string `usage:"Environment variables to set on running containers" short:"e"`
+	Label             []string `usage:"Add labels to the app and the resources it creates (format [type:][name:]key=value) (ex k=v, containers:k=v)" short:"l"`
+	Annotation        []string `usage:"Add annotations to the app and the resources it creates (format [type:][name:]key=value) (ex k=v, containers:k=v)"`
+	Dangerous         bool     `usage:"Automatically approve all privileges requested by the application"`
+	Output            string   `usage:"Output API request without creating app (json, yaml)" short:"o"`
+	TargetNamespace   string   `usage:"The name of the namespace to be created and deleted for the application resources"`
+	NotifyUpgrade     *bool    `usage:"If true and the app is configured for auto-upgrades, you will be notified in the CLI when an upgrade is available and must confirm it"`
+	AutoUpgrade       *bool    `usage:"Enabled automatic upgrades."`
+	Interval          string   `usage:"If configured for auto-upgrade, this is the time interval at which to check for new releases (ex: 1h, 5m)"`
+	RollbackAfter     string   `usage:"If configured for auto-upgrade, roll back an upgrade that is not ready within this time and skip its image (ex: 10m)" name:"auto-upgrade-rollback-after"`
+	MaintenanceWindow string   `usage:"If configured for auto-upgrade, only apply upgrades during this window, a cron schedule followed by a duration (ex: \"0 2 * * 6 4h\")"`
+	Memory            []string `usage:"Set memory request and limit for containers (format [container=]quantity) (ex 512Mi, web=1Gi)" short:"m"`
+	CPU               []string `usage:"Set CPU request and limit for containers (format [container=]quantity) (ex 500m, web=2)"`
+	NodeSelector      []string `usage:"Schedule containers and jobs on nodes with a label (format [container:]key=value) (ex web:kubernetes.io/arch=arm64)"`
 }
 
 func (s RunArgs) ToOpts() (client.AppRunOptions, error) {
@@ -137,6 +166,8 @@ func (s RunArgs) ToOpts() (client.AppRunOptions, error) {
 	opts.AutoUpgrade = s.AutoUpgrade
 	opts.NotifyUpgrade = s.NotifyUpgrade
 	opts.AutoUpgradeInterval = s.Interval
+	opts.RollbackAfter = s.RollbackAfter
+	opts.MaintenanceWindow = s.MaintenanceWindow
 
 	opts.Volumes, err = v1.ParseVolumes(s.Volume, true)
 	if err != nil {
@@ -155,6 +186,21 @@ func (s RunArgs) ToOpts() (client.AppRunOptions, error) {
 
 	opts.Env = v1.ParseNameValues(true, s.Env...)
 
+	opts.Memory, err = v1.ParseResourceBindings(s.Memory)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.CPU, err = v1.ParseResourceBindings(s.CPU)
+	if err != nil {
+		return opts, err
+	}
+
+	opts.NodeSelectors, err = v1.ParseNodeSelectors(s.NodeSelector)
+	if err != nil {
+		return opts, err
+	}
+
 	opts.Labels, err = v1.ParseScopedLabels(s.Label...)
 	if err != nil {
 		return opts, err
@@ -29,6 +29,7 @@ acorn secret`,
 	cmd.AddCommand(NewSecretDelete(c))
 	cmd.AddCommand(NewSecretExpose(c))
 	cmd.AddCommand(NewSecretEncrypt(c))
+	cmd.AddCommand(NewSecretRotate(c))
 	return cmd
 }
 
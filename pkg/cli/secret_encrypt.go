@@ -10,7 +10,9 @@ import (
 	"github.com/AlecAivazis/survey/v2"
 	cli "github.com/acorn-io/acorn/pkg/cli/builder"
 	"github.com/acorn-io/acorn/pkg/cli/builder/table"
+	"github.com/acorn-io/acorn/pkg/encryption"
 	"github.com/acorn-io/acorn/pkg/encryption/nacl"
+	"github.com/acorn-io/acorn/pkg/tables"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -28,6 +30,10 @@ func NewSecretEncrypt(c client.CommandContext) *cobra.Command {
 type Encrypt struct {
 	PlaintextStdin bool     `usage:"Take the plaintext from stdin"`
 	PublicKey      []string `usage:"Pass one or more cluster publicKey values"`
+	RotateKeys     bool     `usage:"Generate a new primary key for the project, older keys are kept to decrypt existing values (admin only)"`
+	DeleteKey      []string `usage:"Delete one or more retired keys of the project (admin only)"`
+	Force          bool     `usage:"Delete the keys even if values in apps can only be decrypted with them"`
+	KeyReport      bool     `usage:"List the keys of the project and the values in apps that can only be decrypted with a retired key"`
 	client         client.ClientFactory
 }
 
@@ -40,6 +46,13 @@ func (e *Encrypt) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if e.RotateKeys || len(e.DeleteKey) > 0 || e.KeyReport {
+		if len(args) > 0 || e.PlaintextStdin {
+			return fmt.Errorf("no data can be encrypted while managing keys")
+		}
+		return e.manageKeys(cmd, c)
+	}
+
 	if e.PlaintextStdin && len(args) == 0 {
 		contents, err := io.ReadAll(os.Stdin)
 		if err != nil {
@@ -79,8 +92,11 @@ func (e *Encrypt) Run(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
+		// Retired keys are only kept to decrypt existing values
 		for _, key := range info.Spec.PublicKeys {
-			e.PublicKey = append(e.PublicKey, key.KeyID)
+			if key.Primary {
+				e.PublicKey = append(e.PublicKey, key.KeyID)
+			}
 		}
 	}
 
@@ -98,3 +114,84 @@ func (e *Encrypt) Run(cmd *cobra.Command, args []string) error {
 
 	return out.Err()
 }
+
+// manageKeys rotates or deletes the keys of the project and then reports the values that still need the retired keys.
+// Keys are only deleted after checking that no value in the apps can only be decrypted with them, unless forced.
+func (e *Encrypt) manageKeys(cmd *cobra.Command, c client.Client) error {
+	if e.RotateKeys {
+		keyID, err := c.EncryptionKeyRotate(cmd.Context())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Generated primary key %s\n", keyID)
+	}
+
+	apps, err := c.AppList(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if len(e.DeleteKey) > 0 {
+		info, err := c.Info(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		refs, err := encryption.DeletedKeyReferences(apps, info.Spec.PublicKeys, e.DeleteKey)
+		if err != nil {
+			return err
+		}
+		if len(refs) > 0 {
+			fmt.Println("Values in apps that can only be decrypted with the deleted keys:")
+			if err := writeRetiredKeyReferences(refs); err != nil {
+				return err
+			}
+			if !e.Force {
+				return fmt.Errorf("not deleting keys that values in apps can only be decrypted with, encrypt the values again with the primary key or use --force")
+			}
+			fmt.Println()
+		}
+
+		if err := c.EncryptionKeyDelete(cmd.Context(), e.Force, e.DeleteKey...); err != nil {
+			return err
+		}
+		for _, keyID := range e.DeleteKey {
+			fmt.Printf("Deleted key %s\n", keyID)
+		}
+	}
+
+	info, err := c.Info(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	refs, err := encryption.RetiredKeyReferences(apps, info.Spec.PublicKeys)
+	if err != nil {
+		return err
+	}
+
+	keys := table.NewWriter(tables.EncryptionKey, "", false, "")
+	for _, key := range info.Spec.PublicKeys {
+		keys.Write(key)
+	}
+	if err := keys.Close(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if len(refs) == 0 {
+		fmt.Println("No values in apps are encrypted only with retired keys")
+		return nil
+	}
+
+	fmt.Println("Values in apps that are encrypted only with retired keys, encrypt them again before deleting the keys:")
+	return writeRetiredKeyReferences(refs)
+}
+
+func writeRetiredKeyReferences(refs []encryption.RetiredKeyReference) error {
+	out := table.NewWriter(tables.RetiredKeyReference, "", false, "")
+	for _, ref := range refs {
+		out.Write(ref)
+	}
+	return out.Close()
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewSecretRotate(c client.CommandContext) *cobra.Command {
+	cmd := cli.Command(&SecretRotate{client: c.ClientFactory}, cobra.Command{
+		Use: "rotate [SECRET_NAME...]",
+		Example: `
+# Regenerate the token of the db-password secret of my-app
+acorn secret rotate my-app.db-password`,
+		SilenceUsage: true,
+		Short:        "Regenerate the value of a generated token or basic secret",
+		Args:         cobra.MinimumNArgs(1),
+	})
+	return cmd
+}
+
+type SecretRotate struct {
+	client client.ClientFactory
+}
+
+func (a *SecretRotate) Run(cmd *cobra.Command, args []string) error {
+	client, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	for _, secret := range args {
+		if _, err := client.SecretRotate(cmd.Context(), secret); err != nil {
+			return fmt.Errorf("rotating %s: %w", secret, err)
+		}
+		fmt.Println(secret)
+	}
+
+	return nil
+}
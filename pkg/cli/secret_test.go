@@ -221,6 +221,44 @@ func TestSecret(t *testing.T) {
 			wantErr: false,
 			wantOut: "ACORNENC:e30\n",
 		},
+		{
+			name: "acorn secret rotate found.secret", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader("y\n"),
+			},
+			args: args{
+				args:   []string{"rotate", "found.secret"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "found.secret\n",
+		},
+		{
+			name: "acorn secret rotate dne", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader("y\n"),
+			},
+			args: args{
+				args:   []string{"rotate", "dne"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: true,
+			wantOut: "rotating dne: error: Secret dne does not exist",
+		},
 	}
 	for _, tt := range tests {
 		r, w, _ := os.Pipe()
@@ -3,7 +3,9 @@ package testdata
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
+	"strings"
 
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
@@ -59,6 +61,18 @@ func (m *MockClient) AppGet(ctx context.Context, name string) (*apiv1.App, error
 			Spec:       v1.AppInstanceSpec{Secrets: []v1.SecretBinding{v1.SecretBinding{Secret: "found.secret", Target: "found"}}},
 			Status:     v1.AppInstanceStatus{Ready: true},
 		}, nil
+	case "scaling":
+		return &apiv1.App{
+			TypeMeta:   metav1.TypeMeta{},
+			ObjectMeta: metav1.ObjectMeta{Name: "scaling"},
+			Status: v1.AppInstanceStatus{
+				Columns: v1.AppColumns{
+					Healthy:  "2/5",
+					UpToDate: "2",
+					Replicas: "2/5",
+				},
+			},
+		}, nil
 	case "found.container":
 		return &apiv1.App{
 			TypeMeta:   metav1.TypeMeta{},
@@ -246,6 +260,20 @@ func (m *MockClient) SecretUpdate(ctx context.Context, name string, data map[str
 	return nil, nil
 }
 
+func (m *MockClient) SecretRotate(ctx context.Context, name string) (*apiv1.Secret, error) {
+	switch name {
+	case "dne":
+		return nil, fmt.Errorf("error: Secret %s does not exist", name)
+	case "found.secret":
+		return &apiv1.Secret{
+			TypeMeta:   metav1.TypeMeta{},
+			ObjectMeta: metav1.ObjectMeta{Name: "found.secret"},
+			Type:       "token",
+		}, nil
+	}
+	return nil, nil
+}
+
 func (m *MockClient) SecretDelete(ctx context.Context, name string) (*apiv1.Secret, error) {
 	switch name {
 	case "dne":
@@ -335,6 +363,93 @@ func (m *MockClient) VolumeDelete(ctx context.Context, name string) (*apiv1.Volu
 	return nil, nil
 }
 
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func mockArchiveIO(stdout string) *term.ExecIO {
+	exit := make(chan term.ExitCode, 1)
+	exit <- term.ExitCode{}
+	return &term.ExecIO{
+		Stdin:    nopWriteCloser{io.Discard},
+		Stdout:   io.NopCloser(strings.NewReader(stdout)),
+		Stderr:   io.NopCloser(strings.NewReader("")),
+		ExitCode: exit,
+	}
+}
+
+func (m *MockClient) VolumeExport(ctx context.Context, name string, opts *client.VolumeArchiveOptions) (*term.ExecIO, error) {
+	if name != "found.volume" {
+		return nil, fmt.Errorf("error: volume %s does not exist", name)
+	}
+	return mockArchiveIO("archive"), nil
+}
+
+func (m *MockClient) VolumeImport(ctx context.Context, name string, opts *client.VolumeArchiveOptions) (*term.ExecIO, error) {
+	if name != "found.volume" {
+		return nil, fmt.Errorf("error: volume %s does not exist", name)
+	}
+	return mockArchiveIO(""), nil
+}
+
+func (m *MockClient) VolumeSnapshotCreate(ctx context.Context, volumeName string, opts *client.VolumeSnapshotCreateOptions) (*apiv1.VolumeSnapshot, error) {
+	switch volumeName {
+	case "dne":
+		return nil, fmt.Errorf("error: volume %s does not exist", volumeName)
+	case "found.volume":
+		name := "found-volume-abcde"
+		if opts != nil && opts.Name != "" {
+			name = opts.Name
+		}
+		return &apiv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       apiv1.VolumeSnapshotSpec{Volume: volumeName},
+		}, nil
+	}
+	return nil, nil
+}
+
+func (m *MockClient) VolumeSnapshotList(ctx context.Context) ([]apiv1.VolumeSnapshot, error) {
+	return []apiv1.VolumeSnapshot{{
+		ObjectMeta: metav1.ObjectMeta{Name: "found-snapshot"},
+		Spec:       apiv1.VolumeSnapshotSpec{Volume: "found.volume"},
+		Status:     apiv1.VolumeSnapshotStatus{AppName: "found", VolumeName: "volume", ReadyToUse: true, Status: "ready"},
+	}}, nil
+}
+
+func (m *MockClient) VolumeSnapshotGet(ctx context.Context, name string) (*apiv1.VolumeSnapshot, error) {
+	switch name {
+	case "dne":
+		return nil, fmt.Errorf("error: volume snapshot %s does not exist", name)
+	case "found-snapshot":
+		return &apiv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "found-snapshot"},
+			Spec:       apiv1.VolumeSnapshotSpec{Volume: "found.volume"},
+			Status:     apiv1.VolumeSnapshotStatus{AppName: "found", VolumeName: "volume", ReadyToUse: true, Status: "ready"},
+		}, nil
+	}
+	return nil, nil
+}
+
+func (m *MockClient) VolumeSnapshotDelete(ctx context.Context, name string) (*apiv1.VolumeSnapshot, error) {
+	switch name {
+	case "found-snapshot":
+		return &apiv1.VolumeSnapshot{}, nil
+	}
+	return nil, nil
+}
+
+func (m *MockClient) VolumeSnapshotRestore(ctx context.Context, snapshotName, volumeName string) error {
+	if snapshotName != "found-snapshot" {
+		return fmt.Errorf("error: volume snapshot %s does not exist", snapshotName)
+	}
+	return nil
+}
+
 func (m *MockClient) ImageList(ctx context.Context) ([]apiv1.Image, error) {
 	return []apiv1.Image{apiv1.Image{
 		TypeMeta:   metav1.TypeMeta{},
@@ -464,6 +579,14 @@ func (m *MockClient) Info(ctx context.Context) (*apiv1.Info, error) {
 	}, nil
 }
 
+func (m *MockClient) EncryptionKeyRotate(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+func (m *MockClient) EncryptionKeyDelete(ctx context.Context, force bool, keyIDs ...string) error {
+	return nil
+}
+
 func (m *MockClient) GetNamespace() string { return "" }
 
 func (m *MockClient) GetClient() kclient.WithWatch { return nil }
@@ -476,6 +599,48 @@ func (m *MockClient) AppConfirmUpgrade(ctx context.Context, name string) error {
 	return nil
 }
 
+func (m *MockClient) AppDiff(ctx context.Context, name string, opts *client.AppUpdateOptions) (*apiv1.AppDiff, error) {
+	switch name {
+	case "dne":
+		return nil, fmt.Errorf("error: app %s does not exist", name)
+	case "found":
+		return &apiv1.AppDiff{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Changes: []apiv1.AppSpecChange{
+				{Action: apiv1.AppSpecChangeAdded, Kind: "container", Path: "containers.db"},
+				{Action: apiv1.AppSpecChangeChanged, Kind: "env", Path: "containers.web.env.FOO", Old: "a", New: "b"},
+			},
+		}, nil
+	}
+	return nil, nil
+}
+
+func (m *MockClient) AppHistory(ctx context.Context, name string) ([]apiv1.AppRevision, error) {
+	switch name {
+	case "dne":
+		return nil, fmt.Errorf("error: app %s does not exist", name)
+	case "found":
+		return []apiv1.AppRevision{
+			{Revision: 1, AppImage: v1.AppImage{Name: "found-image:v1", Digest: "sha256:1111"}},
+			{Revision: 2, AppImage: v1.AppImage{Name: "found-image:v2", Digest: "sha256:2222"}},
+		}, nil
+	}
+	return nil, nil
+}
+
+func (m *MockClient) AppRollback(ctx context.Context, name string, revision int64) (*apiv1.App, error) {
+	revisions, err := m.AppHistory(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.RollbackTarget(revisions, revision); err != nil {
+		return nil, fmt.Errorf("app %s: %w", name, err)
+	}
+	return &apiv1.App{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}, nil
+}
+
 func (m *MockClient) AcornImageBuildGet(ctx context.Context, name string) (*apiv1.AcornImageBuild, error) {
 	//TODO implement me
 	panic("implement me")
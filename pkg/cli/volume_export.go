@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/client/term"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+func NewVolumeExport(c client.CommandContext) *cobra.Command {
+	cmd := cli.Command(&VolumeExport{client: c.ClientFactory}, cobra.Command{
+		Use: "export [flags] VOLUME_NAME",
+		Example: `
+# Export the contents of a volume as a tar archive
+acorn volume export pvc-5f7c3e0a > data.tar
+
+# Export only a directory of the volume
+acorn volume export --sub-path backups pvc-5f7c3e0a > backups.tar`,
+		SilenceUsage: true,
+		Short:        "Export the contents of a volume as a tar archive to stdout",
+		Args:         cobra.ExactArgs(1),
+	})
+	return cmd
+}
+
+type VolumeExport struct {
+	SubPath string `usage:"Directory of the volume to export, the root of the volume if not set"`
+	client  client.ClientFactory
+}
+
+func (a *VolumeExport) Run(cmd *cobra.Command, args []string) error {
+	c, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	cIO, err := c.VolumeExport(cmd.Context(), args[0], &client.VolumeArchiveOptions{
+		SubPath: a.SubPath,
+	})
+	if err != nil {
+		return err
+	}
+
+	return copyArchive(cIO, nil, cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
+
+// copyArchive streams the archive to and from the archive command and waits for it to exit. Unlike term.Pipe, stdin
+// is not closed once in is consumed as that closes the whole connection. tar stops reading at the end of the archive.
+func copyArchive(cIO *term.ExecIO, in io.Reader, out, errOut io.Writer) error {
+	if in != nil {
+		go func() {
+			_, _ = io.Copy(cIO.Stdin, in)
+		}()
+	}
+
+	eg := errgroup.Group{}
+	eg.Go(func() error {
+		_, err := io.Copy(out, cIO.Stdout)
+		return err
+	})
+	eg.Go(func() error {
+		_, err := io.Copy(errOut, cIO.Stderr)
+		return err
+	})
+	copyErr := eg.Wait()
+
+	exit := <-cIO.ExitCode
+	if exit.Err != nil {
+		return exit.Err
+	} else if exit.Code != 0 {
+		return fmt.Errorf("archive command exited with code %d", exit.Code)
+	}
+	return copyErr
+}
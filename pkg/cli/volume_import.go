@@ -0,0 +1,44 @@
+package cli
+
+import (
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewVolumeImport(c client.CommandContext) *cobra.Command {
+	cmd := cli.Command(&VolumeImport{client: c.ClientFactory}, cobra.Command{
+		Use: "import [flags] VOLUME_NAME",
+		Example: `
+# Import a tar archive into a volume, existing files with the same name are overwritten
+acorn volume import pvc-5f7c3e0a < data.tar
+
+# Import into a directory of the volume
+acorn volume import --sub-path seed pvc-5f7c3e0a < seed.tar`,
+		SilenceUsage: true,
+		Short:        "Import a tar archive from stdin into a volume",
+		Args:         cobra.ExactArgs(1),
+	})
+	return cmd
+}
+
+type VolumeImport struct {
+	SubPath string `usage:"Directory of the volume to import to, the root of the volume if not set"`
+	client  client.ClientFactory
+}
+
+func (a *VolumeImport) Run(cmd *cobra.Command, args []string) error {
+	c, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	cIO, err := c.VolumeImport(cmd.Context(), args[0], &client.VolumeArchiveOptions{
+		SubPath: a.SubPath,
+	})
+	if err != nil {
+		return err
+	}
+
+	return copyArchive(cIO, cmd.InOrStdin(), cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
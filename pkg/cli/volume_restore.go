@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewVolumeRestore(c client.CommandContext) *cobra.Command {
+	cmd := cli.Command(&VolumeRestore{client: c.ClientFactory}, cobra.Command{
+		Use: "restore [flags] SNAPSHOT_NAME",
+		Example: `
+# Restore a snapshot to a new volume and bind it to the data volume of a new app
+acorn volume restore --as db-data db-backup
+acorn run -v db-data:data .`,
+		SilenceUsage: true,
+		Short:        "Restore a volume snapshot to a new volume",
+		Args:         cobra.ExactArgs(1),
+	})
+	return cmd
+}
+
+type VolumeRestore struct {
+	As     string `usage:"Name of the restored volume, used to bind it to an app with --volume NAME:TARGET"`
+	client client.ClientFactory
+}
+
+func (a *VolumeRestore) Run(cmd *cobra.Command, args []string) error {
+	if a.As == "" {
+		return fmt.Errorf("--as is required to name the restored volume")
+	}
+
+	client, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	if err := client.VolumeSnapshotRestore(cmd.Context(), args[0], a.As); err != nil {
+		return fmt.Errorf("restoring %s: %w", args[0], err)
+	}
+
+	fmt.Println(a.As)
+	return nil
+}
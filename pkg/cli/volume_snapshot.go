@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"fmt"
+
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+func NewVolumeSnapshot(c client.CommandContext) *cobra.Command {
+	cmd := cli.Command(&VolumeSnapshot{client: c.ClientFactory}, cobra.Command{
+		Use: "snapshot [flags] VOLUME_NAME",
+		Example: `
+# Take a snapshot of a volume
+acorn volume snapshot pvc-5f7c3e0a-2d7b-4c39-9f0e-6a1c2b3d4e5f
+
+# Take a snapshot with a specific name
+acorn volume snapshot --name db-backup pvc-5f7c3e0a-2d7b-4c39-9f0e-6a1c2b3d4e5f`,
+		SilenceUsage: true,
+		Short:        "Take a snapshot of a volume",
+		Args:         cobra.ExactArgs(1),
+	})
+	return cmd
+}
+
+type VolumeSnapshot struct {
+	Name   string `usage:"Name of the snapshot, generated from the app and volume name if not set"`
+	client client.ClientFactory
+}
+
+func (a *VolumeSnapshot) Run(cmd *cobra.Command, args []string) error {
+	c, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := c.VolumeSnapshotCreate(cmd.Context(), args[0], &client.VolumeSnapshotCreateOptions{
+		Name: a.Name,
+	})
+	if err != nil {
+		return fmt.Errorf("snapshotting %s: %w", args[0], err)
+	}
+
+	fmt.Println(snapshot.Name)
+	return nil
+}
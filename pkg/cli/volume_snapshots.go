@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+
+	cli "github.com/acorn-io/acorn/pkg/cli/builder"
+	"github.com/acorn-io/acorn/pkg/cli/builder/table"
+	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/acorn-io/acorn/pkg/tables"
+	"github.com/spf13/cobra"
+	"k8s.io/utils/strings/slices"
+)
+
+func NewVolumeSnapshots(c client.CommandContext) *cobra.Command {
+	cmd := cli.Command(&VolumeSnapshots{client: c.ClientFactory}, cobra.Command{
+		Use: "snapshots [flags] [SNAPSHOT_NAME...]",
+		Example: `
+acorn volume snapshots`,
+		SilenceUsage: true,
+		Short:        "List volume snapshots",
+	})
+	cmd.AddCommand(NewVolumeSnapshotDelete(c))
+	return cmd
+}
+
+type VolumeSnapshots struct {
+	Quiet  bool   `usage:"Output only names" short:"q"`
+	Output string `usage:"Output format (json, yaml, {{gotemplate}})" short:"o"`
+	client client.ClientFactory
+}
+
+func (a *VolumeSnapshots) Run(cmd *cobra.Command, args []string) error {
+	client, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	out := table.NewWriter(tables.VolumeSnapshot, system.UserNamespace(), a.Quiet, a.Output)
+
+	if len(args) == 1 {
+		snapshot, err := client.VolumeSnapshotGet(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		out.Write(snapshot)
+		return out.Err()
+	}
+
+	snapshots, err := client.VolumeSnapshotList(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	for _, snapshot := range snapshots {
+		if len(args) == 0 || slices.Contains(args, snapshot.Name) {
+			out.Write(snapshot)
+		}
+	}
+
+	return out.Err()
+}
+
+func NewVolumeSnapshotDelete(c client.CommandContext) *cobra.Command {
+	cmd := cli.Command(&VolumeSnapshotDelete{client: c.ClientFactory}, cobra.Command{
+		Use:          "rm [SNAPSHOT_NAME...]",
+		Example:      `acorn volume snapshots rm my-snapshot`,
+		SilenceUsage: true,
+		Short:        "Delete a volume snapshot",
+	})
+	return cmd
+}
+
+type VolumeSnapshotDelete struct {
+	client client.ClientFactory
+}
+
+func (a *VolumeSnapshotDelete) Run(cmd *cobra.Command, args []string) error {
+	client, err := a.client.CreateDefault()
+	if err != nil {
+		return err
+	}
+
+	for _, snapshot := range args {
+		deleted, err := client.VolumeSnapshotDelete(cmd.Context(), snapshot)
+		if err != nil {
+			return fmt.Errorf("deleting %s: %w", snapshot, err)
+		}
+		if deleted != nil {
+			fmt.Println(snapshot)
+		} else {
+			fmt.Printf("Error: No such volume snapshot: %s\n", snapshot)
+		}
+	}
+
+	return nil
+}
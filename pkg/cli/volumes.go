@@ -20,6 +20,11 @@ acorn volume`,
 		Short:        "Manage volumes",
 	})
 	cmd.AddCommand(NewVolumeDelete(c))
+	cmd.AddCommand(NewVolumeSnapshot(c))
+	cmd.AddCommand(NewVolumeSnapshots(c))
+	cmd.AddCommand(NewVolumeRestore(c))
+	cmd.AddCommand(NewVolumeExport(c))
+	cmd.AddCommand(NewVolumeImport(c))
 	return cmd
 }
 
@@ -164,6 +164,196 @@ func TestVolume(t *testing.T) {
 			wantErr: true,
 			wantOut: "Error: No such volume: dne\n",
 		},
+		{
+			name: "acorn volume snapshot found.volume", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"snapshot", "found.volume"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "found-volume-abcde\n",
+		},
+		{
+			name: "acorn volume snapshot --name backup found.volume", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"snapshot", "--name", "backup", "found.volume"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "backup\n",
+		},
+		{
+			name: "acorn volume snapshot dne", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"snapshot", "dne"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: true,
+			wantOut: "snapshotting dne: error: volume dne does not exist",
+		},
+		{
+			name: "acorn volume snapshots", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"snapshots"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "NAME             VOLUME         APP-NAME   BOUND-VOLUME   SIZE      STATUS    CREATED\nfound-snapshot   found.volume   found      volume         <nil>     ready     292y ago\n",
+		},
+		{
+			name: "acorn volume snapshots rm found-snapshot", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"snapshots", "rm", "found-snapshot"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "found-snapshot\n",
+		},
+		{
+			name: "acorn volume restore --as db-data found-snapshot", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"restore", "--as", "db-data", "found-snapshot"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "db-data\n",
+		},
+		{
+			name: "acorn volume restore found-snapshot", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"restore", "found-snapshot"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: true,
+			wantOut: "--as is required to name the restored volume",
+		},
+		{
+			name: "acorn volume export found.volume", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"export", "found.volume"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "archive",
+		},
+		{
+			name: "acorn volume export dne-volume", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"export", "dne-volume"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: true,
+			wantOut: "error: volume dne-volume does not exist",
+		},
+		{
+			name: "acorn volume import --sub-path seed found.volume", fields: fields{
+				All:    false,
+				Quiet:  false,
+				Output: "",
+			},
+			commandContext: client.CommandContext{
+				ClientFactory: &testdata.MockClientFactory{},
+				StdOut:        w,
+				StdErr:        w,
+				StdIn:         strings.NewReader(""),
+			},
+			args: args{
+				args:   []string{"import", "--sub-path", "seed", "found.volume"},
+				client: &testdata.MockClient{},
+			},
+			wantErr: false,
+			wantOut: "",
+		},
 	}
 	for _, tt := range tests {
 		r, w, _ := os.Pipe()
@@ -14,7 +14,9 @@ import (
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/run"
 	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/acorn/pkg/tags"
 	"github.com/acorn-io/baaah/pkg/typed"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -45,23 +47,28 @@ func ToApp(namespace, image string, opts *AppRunOptions) *apiv1.App {
 			Labels:      appScoped(opts.Labels),
 		},
 		Spec: v1.AppInstanceSpec{
-			Image:               image,
-			PublishMode:         opts.PublishMode,
-			DeployArgs:          opts.DeployArgs,
-			Volumes:             opts.Volumes,
-			Secrets:             opts.Secrets,
-			Links:               opts.Links,
-			Ports:               opts.Ports,
-			Profiles:            opts.Profiles,
-			DevMode:             opts.DevMode,
-			Permissions:         opts.Permissions,
-			Environment:         opts.Env,
-			Labels:              opts.Labels,
-			Annotations:         opts.Annotations,
-			TargetNamespace:     opts.TargetNamespace,
-			AutoUpgrade:         opts.AutoUpgrade,
-			NotifyUpgrade:       opts.NotifyUpgrade,
-			AutoUpgradeInterval: opts.AutoUpgradeInterval,
+			Image:                        image,
+			PublishMode:                  opts.PublishMode,
+			DeployArgs:                   opts.DeployArgs,
+			Volumes:                      opts.Volumes,
+			Secrets:                      opts.Secrets,
+			Links:                        opts.Links,
+			Ports:                        opts.Ports,
+			Profiles:                     opts.Profiles,
+			DevMode:                      opts.DevMode,
+			Permissions:                  opts.Permissions,
+			Environment:                  opts.Env,
+			Labels:                       opts.Labels,
+			Annotations:                  opts.Annotations,
+			TargetNamespace:              opts.TargetNamespace,
+			AutoUpgrade:                  opts.AutoUpgrade,
+			NotifyUpgrade:                opts.NotifyUpgrade,
+			AutoUpgradeInterval:          opts.AutoUpgradeInterval,
+			AutoUpgradeRollbackAfter:     opts.RollbackAfter,
+			AutoUpgradeMaintenanceWindow: opts.MaintenanceWindow,
+			Memory:                       opts.Memory,
+			CPU:                          opts.CPU,
+			NodeSelectors:                opts.NodeSelectors,
 		},
 	}
 }
@@ -116,6 +123,9 @@ func ToAppUpdate(ctx context.Context, c Client, name string, opts *AppUpdateOpti
 	app.Spec.Environment = mergeEnv(app.Spec.Environment, opts.Env)
 	app.Spec.Labels = mergeLabels(app.Spec.Labels, opts.Labels)
 	app.Spec.Annotations = mergeLabels(app.Spec.Annotations, opts.Annotations)
+	app.Spec.Memory = mergeResources(app.Spec.Memory, opts.Memory)
+	app.Spec.CPU = mergeResources(app.Spec.CPU, opts.CPU)
+	app.Spec.NodeSelectors = mergeNodeSelectors(app.Spec.NodeSelectors, opts.NodeSelectors)
 	app.Spec.DeployArgs = typed.Concat(app.Spec.DeployArgs, opts.DeployArgs)
 	if len(opts.Profiles) > 0 {
 		app.Spec.Profiles = opts.Profiles
@@ -141,6 +151,12 @@ func ToAppUpdate(ctx context.Context, c Client, name string, opts *AppUpdateOpti
 	if opts.AutoUpgradeInterval != "" {
 		app.Spec.AutoUpgradeInterval = opts.AutoUpgradeInterval
 	}
+	if opts.RollbackAfter != "" {
+		app.Spec.AutoUpgradeRollbackAfter = opts.RollbackAfter
+	}
+	if opts.MaintenanceWindow != "" {
+		app.Spec.AutoUpgradeMaintenanceWindow = opts.MaintenanceWindow
+	}
 
 	return app, nil
 }
@@ -153,6 +169,27 @@ func (c *client) appUpdate(ctx context.Context, name string, opts *AppUpdateOpti
 	return app, translatePermissions(c.Client.Update(ctx, app))
 }
 
+// AppDiff is a dry run of AppUpdate. The app is not changed, instead the changes the update would make to the
+// AppSpec of the app are returned.
+func (c *client) AppDiff(ctx context.Context, name string, opts *AppUpdateOptions) (*apiv1.AppDiff, error) {
+	app, err := ToAppUpdate(ctx, c, name, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &apiv1.AppDiff{
+		Spec: app.Spec,
+	}
+	err = c.RESTClient.Post().
+		Namespace(app.Namespace).
+		Resource("apps").
+		Name(app.Name).
+		SubResource("diff").
+		Body(result).
+		Do(ctx).Into(result)
+	return result, err
+}
+
 func translatePermissions(err error) error {
 	if err == nil {
 		return err
@@ -314,6 +351,42 @@ func mergeVolumes(appVolumes, optsVolumes []v1.VolumeBinding) []v1.VolumeBinding
 	return appVolumes
 }
 
+func mergeResources(appResources, optsResources []v1.ResourceBinding) []v1.ResourceBinding {
+	for _, newResource := range optsResources {
+		found := false
+		for i, existingResource := range appResources {
+			if existingResource.Target == newResource.Target {
+				appResources[i] = newResource
+				found = true
+				break
+			}
+		}
+		if !found {
+			appResources = append(appResources, newResource)
+		}
+	}
+
+	return appResources
+}
+
+func mergeNodeSelectors(appSelectors, optsSelectors []v1.NodeSelectorBinding) []v1.NodeSelectorBinding {
+	for _, newSelector := range optsSelectors {
+		found := false
+		for i, existingSelector := range appSelectors {
+			if existingSelector.Target == newSelector.Target && existingSelector.Key == newSelector.Key {
+				appSelectors[i] = newSelector
+				found = true
+				break
+			}
+		}
+		if !found {
+			appSelectors = append(appSelectors, newSelector)
+		}
+	}
+
+	return appSelectors
+}
+
 func mergeLabels(appLabels, optsLabels []v1.ScopedLabel) []v1.ScopedLabel {
 	for _, newLabel := range optsLabels {
 		found := false
@@ -490,3 +563,80 @@ func (c *client) AppPullImage(ctx context.Context, name string) error {
 		SubResource("pullimage").
 		Body(&apiv1.AppPullImage{}).Do(ctx).Error()
 }
+
+func (c *client) AppHistory(ctx context.Context, name string) ([]apiv1.AppRevision, error) {
+	result := &apiv1.AppHistory{}
+	err := c.RESTClient.Get().
+		Namespace(c.Namespace).
+		Resource("apps").
+		Name(name).
+		SubResource("revisions").
+		Do(ctx).Into(result)
+	return result.Revisions, err
+}
+
+func (c *client) AppRollback(ctx context.Context, name string, revision int64) (result *apiv1.App, err error) {
+	for i := 0; i < 5; i++ {
+		result, err = c.appRollback(ctx, name, revision)
+		if apierrors.IsConflict(err) {
+			continue
+		}
+		return
+	}
+	return
+}
+
+func (c *client) appRollback(ctx context.Context, name string, revision int64) (*apiv1.App, error) {
+	revisions, err := c.AppHistory(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := RollbackTarget(revisions, revision)
+	if err != nil {
+		return nil, fmt.Errorf("app %s: %w", name, err)
+	}
+
+	app, err := c.AppGet(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	app.Spec = *target.Spec.DeepCopy()
+	app.Spec.Image = PinnedImage(target.AppImage, target.Spec.Image)
+	return app, c.Client.Update(ctx, app)
+}
+
+// RollbackTarget finds the revision to roll back to. A revision of 0 selects the revision before the current one,
+// which is always the newest.
+func RollbackTarget(revisions []apiv1.AppRevision, revision int64) (*apiv1.AppRevision, error) {
+	if revision == 0 {
+		if len(revisions) < 2 {
+			return nil, fmt.Errorf("no previous revision to roll back to")
+		}
+		return &revisions[len(revisions)-2], nil
+	}
+
+	for i := range revisions {
+		if revisions[i].Revision == revision {
+			return &revisions[i], nil
+		}
+	}
+	return nil, fmt.Errorf("revision %d not found", revision)
+}
+
+// PinnedImage returns a reference to the exact app image of a revision so that a rollback does not pick up
+// a different image that has since been pushed to the same tag.
+func PinnedImage(appImage v1.AppImage, fallback string) string {
+	if appImage.ID == "" {
+		return fallback
+	}
+	if appImage.Digest == "" || tags.IsLocalReference(appImage.ID) {
+		return appImage.ID
+	}
+	ref, err := name.ParseReference(appImage.ID)
+	if err != nil {
+		return appImage.ID
+	}
+	return ref.Context().Digest(appImage.Digest).String()
+}
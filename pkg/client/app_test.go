@@ -3,6 +3,7 @@ package client
 import (
 	"testing"
 
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/stretchr/testify/assert"
 )
@@ -88,3 +89,30 @@ func TestLabelsAndAnnotations(t *testing.T) {
 	assert.Equal(t, "v2", app.Annotations["anno2"])
 	assert.NotContains(t, app.Annotations, "anno3")
 }
+
+func TestPinnedImage(t *testing.T) {
+	digest := "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	localID := "2222222222222222222222222222222222222222222222222222222222222222"
+
+	assert.Equal(t, "ghcr.io/acorn-io/app@"+digest, PinnedImage(v1.AppImage{ID: "ghcr.io/acorn-io/app:v1", Digest: digest}, "ghcr.io/acorn-io/app:v1"))
+	assert.Equal(t, localID, PinnedImage(v1.AppImage{ID: localID, Digest: digest}, "app:dev"))
+	assert.Equal(t, "app:dev", PinnedImage(v1.AppImage{}, "app:dev"))
+}
+
+func TestRollbackTarget(t *testing.T) {
+	revisions := []apiv1.AppRevision{{Revision: 3}, {Revision: 4}, {Revision: 5}}
+
+	target, err := RollbackTarget(revisions, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), target.Revision)
+
+	target, err = RollbackTarget(revisions, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), target.Revision)
+
+	_, err = RollbackTarget(revisions, 1)
+	assert.EqualError(t, err, "revision 1 not found")
+
+	_, err = RollbackTarget(revisions[:1], 0)
+	assert.EqualError(t, err, "no previous revision to roll back to")
+}
@@ -120,6 +120,11 @@ type AppUpdateOptions struct {
 	AutoUpgrade         *bool
 	NotifyUpgrade       *bool
 	AutoUpgradeInterval string
+	RollbackAfter       string
+	MaintenanceWindow   string
+	Memory              []v1.ResourceBinding
+	CPU                 []v1.ResourceBinding
+	NodeSelectors       []v1.NodeSelectorBinding
 }
 
 type LogOptions apiv1.LogOptions
@@ -142,6 +147,11 @@ type AppRunOptions struct {
 	AutoUpgrade         *bool
 	NotifyUpgrade       *bool
 	AutoUpgradeInterval string
+	RollbackAfter       string
+	MaintenanceWindow   string
+	Memory              []v1.ResourceBinding
+	CPU                 []v1.ResourceBinding
+	NodeSelectors       []v1.NodeSelectorBinding
 }
 
 func (a AppRunOptions) ToUpdate() AppUpdateOptions {
@@ -162,6 +172,11 @@ func (a AppRunOptions) ToUpdate() AppUpdateOptions {
 		AutoUpgrade:         a.AutoUpgrade,
 		NotifyUpgrade:       a.NotifyUpgrade,
 		AutoUpgradeInterval: a.AutoUpgradeInterval,
+		RollbackAfter:       a.RollbackAfter,
+		MaintenanceWindow:   a.MaintenanceWindow,
+		Memory:              a.Memory,
+		CPU:                 a.CPU,
+		NodeSelectors:       a.NodeSelectors,
 	}
 }
 
@@ -180,6 +195,9 @@ func (a AppUpdateOptions) ToRun() AppRunOptions {
 		Permissions:     a.Permissions,
 		Env:             a.Env,
 		TargetNamespace: a.TargetNamespace,
+		Memory:          a.Memory,
+		CPU:             a.CPU,
+		NodeSelectors:   a.NodeSelectors,
 	}
 }
 
@@ -204,9 +222,12 @@ type Client interface {
 	AppStart(ctx context.Context, name string) error
 	AppRun(ctx context.Context, image string, opts *AppRunOptions) (*apiv1.App, error)
 	AppUpdate(ctx context.Context, name string, opts *AppUpdateOptions) (*apiv1.App, error)
+	AppDiff(ctx context.Context, name string, opts *AppUpdateOptions) (*apiv1.AppDiff, error)
 	AppLog(ctx context.Context, name string, opts *LogOptions) (<-chan apiv1.LogMessage, error)
 	AppConfirmUpgrade(ctx context.Context, name string) error
 	AppPullImage(ctx context.Context, name string) error
+	AppHistory(ctx context.Context, name string) ([]apiv1.AppRevision, error)
+	AppRollback(ctx context.Context, name string, revision int64) (*apiv1.App, error)
 
 	CredentialCreate(ctx context.Context, serverAddress, username, password string, skipChecks bool) (*apiv1.Credential, error)
 	CredentialList(ctx context.Context) ([]apiv1.Credential, error)
@@ -219,6 +240,7 @@ type Client interface {
 	SecretGet(ctx context.Context, name string) (*apiv1.Secret, error)
 	SecretExpose(ctx context.Context, name string) (*apiv1.Secret, error)
 	SecretUpdate(ctx context.Context, name string, data map[string][]byte) (*apiv1.Secret, error)
+	SecretRotate(ctx context.Context, name string) (*apiv1.Secret, error)
 	SecretDelete(ctx context.Context, name string) (*apiv1.Secret, error)
 
 	ContainerReplicaList(ctx context.Context, opts *ContainerReplicaListOptions) ([]apiv1.ContainerReplica, error)
@@ -229,6 +251,14 @@ type Client interface {
 	VolumeList(ctx context.Context) ([]apiv1.Volume, error)
 	VolumeGet(ctx context.Context, name string) (*apiv1.Volume, error)
 	VolumeDelete(ctx context.Context, name string) (*apiv1.Volume, error)
+	VolumeExport(ctx context.Context, name string, opts *VolumeArchiveOptions) (*term.ExecIO, error)
+	VolumeImport(ctx context.Context, name string, opts *VolumeArchiveOptions) (*term.ExecIO, error)
+
+	VolumeSnapshotCreate(ctx context.Context, volumeName string, opts *VolumeSnapshotCreateOptions) (*apiv1.VolumeSnapshot, error)
+	VolumeSnapshotList(ctx context.Context) ([]apiv1.VolumeSnapshot, error)
+	VolumeSnapshotGet(ctx context.Context, name string) (*apiv1.VolumeSnapshot, error)
+	VolumeSnapshotDelete(ctx context.Context, name string) (*apiv1.VolumeSnapshot, error)
+	VolumeSnapshotRestore(ctx context.Context, snapshotName, volumeName string) error
 
 	ImageList(ctx context.Context) ([]apiv1.Image, error)
 	ImageGet(ctx context.Context, name string) (*apiv1.Image, error)
@@ -244,6 +274,8 @@ type Client interface {
 	AcornImageBuild(ctx context.Context, file string, opts *AcornImageBuildOptions) (*v1.AppImage, error)
 
 	Info(ctx context.Context) (*apiv1.Info, error)
+	EncryptionKeyRotate(ctx context.Context) (string, error)
+	EncryptionKeyDelete(ctx context.Context, force bool, keyIDs ...string) error
 
 	GetNamespace() string
 	GetClient() kclient.WithWatch
@@ -301,6 +333,14 @@ type ContainerReplicaListOptions struct {
 	App string `json:"app,omitempty"`
 }
 
+type VolumeArchiveOptions struct {
+	SubPath string `json:"subPath,omitempty"`
+}
+
+type VolumeSnapshotCreateOptions struct {
+	Name string `json:"name,omitempty"`
+}
+
 type client struct {
 	Namespace  string
 	Client     kclient.WithWatch
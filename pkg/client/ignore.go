@@ -115,10 +115,22 @@ func (c IgnoreUninstalled) AppUpdate(ctx context.Context, name string, opts *App
 	return c.client.AppUpdate(ctx, name, opts)
 }
 
+func (c IgnoreUninstalled) AppDiff(ctx context.Context, name string, opts *AppUpdateOptions) (*apiv1.AppDiff, error) {
+	return c.client.AppDiff(ctx, name, opts)
+}
+
 func (c IgnoreUninstalled) AppPullImage(ctx context.Context, name string) error {
 	return c.client.AppPullImage(ctx, name)
 }
 
+func (c IgnoreUninstalled) AppHistory(ctx context.Context, name string) ([]apiv1.AppRevision, error) {
+	return c.client.AppHistory(ctx, name)
+}
+
+func (c IgnoreUninstalled) AppRollback(ctx context.Context, name string, revision int64) (*apiv1.App, error) {
+	return c.client.AppRollback(ctx, name, revision)
+}
+
 func (c IgnoreUninstalled) AppConfirmUpgrade(ctx context.Context, name string) error {
 	return c.client.AppConfirmUpgrade(ctx, name)
 }
@@ -155,6 +167,34 @@ func (c IgnoreUninstalled) VolumeDelete(ctx context.Context, name string) (*apiv
 	return ignoreUninstalled(c.client.VolumeDelete(ctx, name))
 }
 
+func (c IgnoreUninstalled) VolumeExport(ctx context.Context, name string, opts *VolumeArchiveOptions) (*term.ExecIO, error) {
+	return c.client.VolumeExport(ctx, name, opts)
+}
+
+func (c IgnoreUninstalled) VolumeImport(ctx context.Context, name string, opts *VolumeArchiveOptions) (*term.ExecIO, error) {
+	return c.client.VolumeImport(ctx, name, opts)
+}
+
+func (c IgnoreUninstalled) VolumeSnapshotCreate(ctx context.Context, volumeName string, opts *VolumeSnapshotCreateOptions) (*apiv1.VolumeSnapshot, error) {
+	return c.client.VolumeSnapshotCreate(ctx, volumeName, opts)
+}
+
+func (c IgnoreUninstalled) VolumeSnapshotList(ctx context.Context) ([]apiv1.VolumeSnapshot, error) {
+	return ignoreUninstalled(c.client.VolumeSnapshotList(ctx))
+}
+
+func (c IgnoreUninstalled) VolumeSnapshotGet(ctx context.Context, name string) (*apiv1.VolumeSnapshot, error) {
+	return c.client.VolumeSnapshotGet(ctx, name)
+}
+
+func (c IgnoreUninstalled) VolumeSnapshotDelete(ctx context.Context, name string) (*apiv1.VolumeSnapshot, error) {
+	return ignoreUninstalled(c.client.VolumeSnapshotDelete(ctx, name))
+}
+
+func (c IgnoreUninstalled) VolumeSnapshotRestore(ctx context.Context, snapshotName, volumeName string) error {
+	return c.client.VolumeSnapshotRestore(ctx, snapshotName, volumeName)
+}
+
 func (c IgnoreUninstalled) ImageList(ctx context.Context) ([]apiv1.Image, error) {
 	return ignoreUninstalled(c.client.ImageList(ctx))
 }
@@ -251,6 +291,10 @@ func (c IgnoreUninstalled) SecretUpdate(ctx context.Context, name string, data m
 	return c.client.SecretUpdate(ctx, name, data)
 }
 
+func (c IgnoreUninstalled) SecretRotate(ctx context.Context, name string) (*apiv1.Secret, error) {
+	return c.client.SecretRotate(ctx, name)
+}
+
 func (c IgnoreUninstalled) SecretDelete(ctx context.Context, name string) (*apiv1.Secret, error) {
 	return c.client.SecretDelete(ctx, name)
 }
@@ -260,3 +304,11 @@ func (c IgnoreUninstalled) Info(ctx context.Context) (*apiv1.Info, error) {
 		return c.client.Info(ctx)
 	})
 }
+
+func (c IgnoreUninstalled) EncryptionKeyRotate(ctx context.Context) (string, error) {
+	return c.client.EncryptionKeyRotate(ctx)
+}
+
+func (c IgnoreUninstalled) EncryptionKeyDelete(ctx context.Context, force bool, keyIDs ...string) error {
+	return c.client.EncryptionKeyDelete(ctx, force, keyIDs...)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -18,3 +19,34 @@ func (c *client) Info(ctx context.Context) (*apiv1.Info, error) {
 
 	return &result.Items[0], nil
 }
+
+// EncryptionKeyRotate generates a new primary encryption key for the project and returns its ID
+func (c *client) EncryptionKeyRotate(ctx context.Context) (string, error) {
+	result := &apiv1.EncryptionKeyRotation{}
+	err := c.RESTClient.Post().
+		Namespace(c.Namespace).
+		Resource("encryptionkeyrotations").
+		Body(&apiv1.EncryptionKeyRotation{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "rotation-",
+				Namespace:    c.Namespace,
+			},
+		}).Do(ctx).Into(result)
+	return result.PrimaryKey, err
+}
+
+// EncryptionKeyDelete deletes retired encryption keys of the project. Unless force is set, the keys are not deleted
+// if values in the apps of the project can only be decrypted with them.
+func (c *client) EncryptionKeyDelete(ctx context.Context, force bool, keyIDs ...string) error {
+	return c.RESTClient.Post().
+		Namespace(c.Namespace).
+		Resource("encryptionkeydeletions").
+		Body(&apiv1.EncryptionKeyDeletion{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "deletion-",
+				Namespace:    c.Namespace,
+			},
+			KeyIDs: keyIDs,
+			Force:  force,
+		}).Do(ctx).Error()
+}
@@ -2,10 +2,13 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -60,6 +63,27 @@ func (c *client) SecretUpdate(ctx context.Context, name string, data map[string]
 	return secret, c.Client.Update(ctx, secret)
 }
 
+func (c *client) SecretRotate(ctx context.Context, name string) (*apiv1.Secret, error) {
+	secret := &apiv1.Secret{}
+	err := c.Client.Get(ctx, kclient.ObjectKey{
+		Name:      name,
+		Namespace: c.Namespace,
+	}, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if secret.Labels[labels.AcornSecretGenerated] != "true" || (secret.Type != "token" && secret.Type != "basic") {
+		return nil, fmt.Errorf("secret %s is not a generated token or basic secret", name)
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[labels.AcornSecretRotate] = time.Now().UTC().Format(time.RFC3339)
+	return secret, c.Client.Update(ctx, secret)
+}
+
 func (c *client) SecretList(ctx context.Context) ([]apiv1.Secret, error) {
 	result := &apiv1.SecretList{}
 	err := c.Client.List(ctx, result, &kclient.ListOptions{
@@ -5,6 +5,9 @@ import (
 	"sort"
 
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/client/term"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/rancher/wrangler/pkg/name"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,3 +53,113 @@ func (c *client) VolumeDelete(ctx context.Context, name string) (*apiv1.Volume,
 		},
 	})
 }
+
+func (c *client) volumeArchive(ctx context.Context, name, subResource string, opts *VolumeArchiveOptions) (*term.ExecIO, error) {
+	if opts == nil {
+		opts = &VolumeArchiveOptions{}
+	}
+
+	req := c.RESTClient.Get().
+		Namespace(c.Namespace).
+		Resource("volumes").
+		Name(name).
+		SubResource(subResource).
+		VersionedParams(&apiv1.VolumeArchiveOptions{
+			SubPath: opts.SubPath,
+		}, scheme.ParameterCodec)
+
+	conn, err := c.Dialer.DialContext(ctx, req.URL().String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return conn.ToExecIO(false), nil
+}
+
+func (c *client) VolumeExport(ctx context.Context, name string, opts *VolumeArchiveOptions) (*term.ExecIO, error) {
+	return c.volumeArchive(ctx, name, "export", opts)
+}
+
+func (c *client) VolumeImport(ctx context.Context, name string, opts *VolumeArchiveOptions) (*term.ExecIO, error) {
+	return c.volumeArchive(ctx, name, "import", opts)
+}
+
+func (c *client) VolumeSnapshotCreate(ctx context.Context, volumeName string, opts *VolumeSnapshotCreateOptions) (*apiv1.VolumeSnapshot, error) {
+	if opts == nil {
+		opts = &VolumeSnapshotCreateOptions{}
+	}
+
+	snapshot := &apiv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opts.Name,
+			Namespace: c.Namespace,
+		},
+		Spec: apiv1.VolumeSnapshotSpec{
+			Volume: volumeName,
+		},
+	}
+
+	if snapshot.Name == "" {
+		vol, err := c.VolumeGet(ctx, volumeName)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.GenerateName = name.SafeConcatName(vol.Status.AppName, vol.Status.VolumeName) + "-"
+	}
+
+	return snapshot, c.Client.Create(ctx, snapshot)
+}
+
+func (c *client) VolumeSnapshotList(ctx context.Context) ([]apiv1.VolumeSnapshot, error) {
+	snapshots := &apiv1.VolumeSnapshotList{}
+	err := c.Client.List(ctx, snapshots, &kclient.ListOptions{
+		Namespace: c.Namespace,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(snapshots.Items, func(i, j int) bool {
+		if snapshots.Items[i].CreationTimestamp.Time == snapshots.Items[j].CreationTimestamp.Time {
+			return snapshots.Items[i].Name < snapshots.Items[j].Name
+		}
+		return snapshots.Items[i].CreationTimestamp.After(snapshots.Items[j].CreationTimestamp.Time)
+	})
+
+	return snapshots.Items, nil
+}
+
+func (c *client) VolumeSnapshotGet(ctx context.Context, name string) (*apiv1.VolumeSnapshot, error) {
+	snapshot := &apiv1.VolumeSnapshot{}
+	return snapshot, c.Client.Get(ctx, kclient.ObjectKey{
+		Name:      name,
+		Namespace: c.Namespace,
+	}, snapshot)
+}
+
+func (c *client) VolumeSnapshotDelete(ctx context.Context, name string) (*apiv1.VolumeSnapshot, error) {
+	// get first to ensure the namespace matches
+	snapshot, err := c.VolumeSnapshotGet(ctx, name)
+	if apierror.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return snapshot, c.Client.Delete(ctx, &apiv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.Namespace,
+		},
+	})
+}
+
+func (c *client) VolumeSnapshotRestore(ctx context.Context, snapshotName, volumeName string) error {
+	return c.RESTClient.Post().
+		Namespace(c.Namespace).
+		Resource("volumesnapshots").
+		Name(snapshotName).
+		SubResource("restore").
+		Body(&apiv1.VolumeSnapshotRestore{
+			VolumeName: volumeName,
+		}).Do(ctx).Error()
+}
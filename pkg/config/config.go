@@ -32,6 +32,12 @@ var (
 
 	// Default HttpEndpointPattern set to enable Let's Encrypt
 	DefaultHttpEndpointPattern = "{{.Container}}-{{.App}}-{{.Hash}}.{{.ClusterDomain}}"
+
+	// AppRevisionHistoryLimitDefault is the default number of app revisions kept for rollback
+	AppRevisionHistoryLimitDefault = 10
+
+	// DefaultVaultPathPrefix keeps the external secrets of each project under its own path
+	DefaultVaultPathPrefix = "secret/data/acorn/{{.Namespace}}"
 )
 
 func complete(c *apiv1.Config, ctx context.Context, getter kclient.Reader) error {
@@ -87,6 +93,21 @@ func complete(c *apiv1.Config, ctx context.Context, getter kclient.Reader) error
 	if c.HttpEndpointPattern == nil || *c.HttpEndpointPattern == "" {
 		c.HttpEndpointPattern = &DefaultHttpEndpointPattern
 	}
+	if c.AppRevisionHistoryLimit == nil {
+		c.AppRevisionHistoryLimit = &AppRevisionHistoryLimitDefault
+	}
+	if c.AutoUpgradeMaintenanceWindow == nil {
+		c.AutoUpgradeMaintenanceWindow = new(string)
+	}
+	if c.VaultAddress == nil {
+		c.VaultAddress = new(string)
+	}
+	if c.VaultKubernetesRole == nil {
+		c.VaultKubernetesRole = new(string)
+	}
+	if c.VaultPathPrefix == nil || *c.VaultPathPrefix == "" {
+		c.VaultPathPrefix = &DefaultVaultPathPrefix
+	}
 
 	return nil
 }
@@ -231,6 +252,21 @@ func merge(oldConfig, newConfig *apiv1.Config) *apiv1.Config {
 	if newConfig.BuilderPerNamespace != nil {
 		mergedConfig.BuilderPerNamespace = newConfig.BuilderPerNamespace
 	}
+	if newConfig.AppRevisionHistoryLimit != nil {
+		mergedConfig.AppRevisionHistoryLimit = newConfig.AppRevisionHistoryLimit
+	}
+	if newConfig.AutoUpgradeMaintenanceWindow != nil {
+		mergedConfig.AutoUpgradeMaintenanceWindow = newConfig.AutoUpgradeMaintenanceWindow
+	}
+	if newConfig.VaultAddress != nil {
+		mergedConfig.VaultAddress = newConfig.VaultAddress
+	}
+	if newConfig.VaultKubernetesRole != nil {
+		mergedConfig.VaultKubernetesRole = newConfig.VaultKubernetesRole
+	}
+	if newConfig.VaultPathPrefix != nil {
+		mergedConfig.VaultPathPrefix = newConfig.VaultPathPrefix
+	}
 
 	return &mergedConfig
 }
@@ -0,0 +1,84 @@
+package appdefinition
+
+import (
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// isAutoscaled returns true if the replicas of the container's deployment should be managed by a
+// HorizontalPodAutoscaler. Containers with volumes that can only be mounted once are run with one replica,
+// unless they set stateful and get a volume for each replica, and stopped apps have no replicas.
+func isAutoscaled(appInstance *v1.AppInstance, container v1.Container) bool {
+	if container.Autoscale == nil {
+		return false
+	}
+	if appInstance.Spec.Stop != nil && *appInstance.Spec.Stop {
+		return false
+	}
+	return container.Stateful || !isStateful(appInstance, container)
+}
+
+func toResourceMetric(name corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: &utilization,
+			},
+		},
+	}
+}
+
+func toHorizontalPodAutoscaler(appInstance *v1.AppInstance, dep *appsv1.Deployment, container v1.Container) kclient.Object {
+	var (
+		autoscale   = container.Autoscale
+		minReplicas = autoscale.Min
+		maxReplicas = autoscale.Max
+		metrics     []autoscalingv2.MetricSpec
+		kind        = "Deployment"
+	)
+
+	if container.Stateful {
+		kind = "StatefulSet"
+	}
+
+	if minReplicas < 1 {
+		minReplicas = 1
+	}
+	if maxReplicas < minReplicas {
+		maxReplicas = minReplicas
+	}
+
+	// When no metrics are defined the autoscaler defaults to 80% average CPU utilization
+	if autoscale.TargetCPU > 0 {
+		metrics = append(metrics, toResourceMetric(corev1.ResourceCPU, autoscale.TargetCPU))
+	}
+	if autoscale.TargetMemory > 0 {
+		metrics = append(metrics, toResourceMetric(corev1.ResourceMemory, autoscale.TargetMemory))
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        dep.Name,
+			Namespace:   appInstance.Status.Namespace,
+			Labels:      containerLabels(appInstance, container, dep.Name),
+			Annotations: containerAnnotations(appInstance, container, dep.Name),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       kind,
+				Name:       dep.Name,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: maxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
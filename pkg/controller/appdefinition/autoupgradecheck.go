@@ -0,0 +1,18 @@
+package appdefinition
+
+import (
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/autoupgrade"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/baaah/pkg/router"
+)
+
+// AutoUpgradeCheck wakes the auto-upgrade daemon when an immediate check of the app was requested. The api-server
+// requests one when a registry reports a push of the app's image; the daemon clears the request once it picks it up.
+func AutoUpgradeCheck(req router.Request, resp router.Response) error {
+	appInstance := req.Object.(*v1.AppInstance)
+	if _, ok := appInstance.Annotations[labels.AcornAutoUpgradeCheck]; ok {
+		autoupgrade.Sync()
+	}
+	return nil
+}
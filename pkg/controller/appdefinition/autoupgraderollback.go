@@ -0,0 +1,156 @@
+package appdefinition
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/autoupgrade"
+	"github.com/acorn-io/acorn/pkg/autoupgrade/validate"
+	"github.com/acorn-io/acorn/pkg/condition"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/baaah/pkg/router"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// trackAutoUpgrade remembers the running image when auto-upgrade moves the app to a new image, so that the
+// upgrade can be rolled back if it doesn't become ready in time. Any other pull ends the tracking.
+func trackAutoUpgrade(appInstance *v1.AppInstance, targetImage string, newImage *v1.AppImage) {
+	rollback := appInstance.Status.AutoUpgradeRollback
+	_, on := autoupgrade.Mode(appInstance.Spec)
+	if !on || appInstance.Spec.AutoUpgradeRollbackAfter == "" ||
+		targetImage != appInstance.Status.AvailableAppImage ||
+		appInstance.Status.AppImage.Digest == "" ||
+		appInstance.Status.AppImage.Digest == newImage.Digest {
+		if rollback != nil {
+			rollback.PreviousAppImage = nil
+		}
+		return
+	}
+
+	if rollback == nil {
+		rollback = &v1.AutoUpgradeRollbackStatus{}
+		appInstance.Status.AutoUpgradeRollback = rollback
+	}
+
+	// If an upgrade is still pending, keep the image from before it, that is the last one known to work
+	if rollback.PreviousAppImage == nil {
+		previous := appInstance.Status.AppImage
+		rollback.PreviousAppImage = &previous
+	}
+	rollback.UpgradeTime = metav1.Now()
+}
+
+// AutoUpgradeRollback pins the app back to its previous image if an automatic upgrade is not ready within
+// spec.autoUpgradeRollbackAfter. The image that was rolled back is skipped by future auto-upgrades.
+func AutoUpgradeRollback(req router.Request, resp router.Response) error {
+	appInstance := req.Object.(*v1.AppInstance)
+	rollback := appInstance.Status.AutoUpgradeRollback
+	if rollback == nil || rollback.PreviousAppImage == nil {
+		return nil
+	}
+
+	cond := condition.Setter(appInstance, resp, v1.AppInstanceConditionRollback)
+	if appInstance.Spec.AutoUpgradeRollbackAfter == "" {
+		rollback.PreviousAppImage = nil
+		cond.Success()
+		return nil
+	}
+
+	after, err := validate.AutoUpgradeRollbackAfter(appInstance.Spec.AutoUpgradeRollbackAfter)
+	if err != nil {
+		return err
+	}
+
+	ready, err := upgradeReady(req, appInstance)
+	if err != nil {
+		return err
+	}
+	if ready {
+		rollback.PreviousAppImage = nil
+		cond.Success()
+		return nil
+	}
+
+	// The condition is not set to an error or transitioning state because that would keep the app from being ready
+	deadline := rollback.UpgradeTime.Add(after)
+	if remaining := time.Until(deadline); remaining > 0 {
+		cond.Set(v1.Condition{
+			Success: true,
+			Message: fmt.Sprintf("waiting until %s for %s to become ready", deadline.UTC().Format(time.RFC3339), appInstance.Status.AppImage.Name),
+		})
+		resp.RetryAfter(remaining)
+		return nil
+	}
+
+	bad, previous := appInstance.Status.AppImage, *rollback.PreviousAppImage
+	skip := bad.Name
+	if bad.Name == previous.Name {
+		// The tag didn't change, new content was pushed to it
+		skip = bad.Digest
+	}
+	if !rollback.IsSkipped(skip, "") {
+		rollback.SkippedImages = append(rollback.SkippedImages, skip)
+	}
+	rollback.PreviousAppImage = nil
+
+	appInstance.Status.AppImage = previous
+	appInstance.Status.AvailableAppImage = ""
+	appInstance.Status.ConfirmUpgradeAppImage = ""
+
+	cond.Set(v1.Condition{
+		Success: true,
+		Message: fmt.Sprintf("rolled back to %s because %s was not ready after %s, it will be skipped by auto-upgrade", previous.Name, skip, after),
+	})
+	return nil
+}
+
+// upgradeReady returns true if the app was ready and all its deployments and StatefulSets were rendered from the new
+// image and have rolled out. They are checked because the app can still be ready from before the upgrade until they
+// are updated.
+func upgradeReady(req router.Request, appInstance *v1.AppInstance) (bool, error) {
+	if !appInstance.Status.Ready {
+		return false, nil
+	}
+
+	var deps appsv1.DeploymentList
+	err := req.List(&deps, &kclient.ListOptions{
+		Namespace: appInstance.Status.Namespace,
+		LabelSelector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornManaged: "true",
+			labels.AcornAppName: appInstance.Name,
+		}),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	err = req.List(&statefulSets, &kclient.ListOptions{
+		Namespace: appInstance.Status.Namespace,
+		LabelSelector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornManaged: "true",
+			labels.AcornAppName: appInstance.Name,
+		}),
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, sts := range statefulSets.Items {
+		deps.Items = append(deps.Items, statefulSetAsDeployment(sts))
+	}
+
+	for _, dep := range deps.Items {
+		// A deployment from before the upgrade can still be in the cache right after the new image is pulled
+		if dep.Annotations[labels.AcornAppImageDigest] != appInstance.Status.AppImage.Digest ||
+			dep.Status.ObservedGeneration != dep.Generation ||
+			dep.Status.UpdatedReplicas != dep.Status.Replicas ||
+			dep.Status.ReadyReplicas != dep.Status.Replicas {
+			return false, nil
+		}
+	}
+	return true, nil
+}
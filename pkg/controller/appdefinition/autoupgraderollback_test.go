@@ -0,0 +1,93 @@
+package appdefinition
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAutoUpgradeRollback(t *testing.T) {
+	dirs, err := os.ReadDir("testdata/autoupgraderollback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range dirs {
+		tester.DefaultTest(t, scheme.Scheme, filepath.Join("testdata/autoupgraderollback", dir.Name()), AutoUpgradeRollback)
+	}
+}
+
+func TestTrackAutoUpgrade(t *testing.T) {
+	app := &v1.AppInstance{
+		Spec: v1.AppInstanceSpec{
+			Image:                    "image:v#",
+			AutoUpgrade:              &[]bool{true}[0],
+			AutoUpgradeRollbackAfter: "10m",
+		},
+		Status: v1.AppInstanceStatus{
+			AvailableAppImage: "image:v3",
+			AppImage: v1.AppImage{
+				Name:   "image:v2",
+				Digest: "sha256:2222",
+			},
+		},
+	}
+
+	trackAutoUpgrade(app, "image:v3", &v1.AppImage{Name: "image:v3", Digest: "sha256:3333"})
+	if assert.NotNil(t, app.Status.AutoUpgradeRollback.PreviousAppImage) {
+		assert.Equal(t, "image:v2", app.Status.AutoUpgradeRollback.PreviousAppImage.Name)
+	}
+	assert.False(t, app.Status.AutoUpgradeRollback.UpgradeTime.IsZero())
+
+	// A manual update ends the tracking
+	app.Status.AvailableAppImage = ""
+	trackAutoUpgrade(app, "image:v4", &v1.AppImage{Name: "image:v4", Digest: "sha256:4444"})
+	assert.Nil(t, app.Status.AutoUpgradeRollback.PreviousAppImage)
+}
+
+// The retry delay depends on the current time, so this case can't be compared against testdata
+func TestAutoUpgradeRollbackWaiting(t *testing.T) {
+	app := &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "app-namespace",
+		},
+		Spec: v1.AppInstanceSpec{
+			Image:                    "image:v#",
+			AutoUpgradeRollbackAfter: "10m",
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-target",
+			AppImage: v1.AppImage{
+				Name:   "image:v2",
+				Digest: "sha256:2222",
+			},
+			AutoUpgradeRollback: &v1.AutoUpgradeRollbackStatus{
+				PreviousAppImage: &v1.AppImage{
+					Name:   "image:v1",
+					Digest: "sha256:1111",
+				},
+				UpgradeTime: metav1.Now(),
+			},
+		},
+	}
+
+	req := tester.NewRequest(t, scheme.Scheme, app)
+	resp := &tester.Response{Client: req.Client.(*tester.Client)}
+	if err := AutoUpgradeRollback(req, resp); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "image:v2", app.Status.AppImage.Name)
+	assert.NotNil(t, app.Status.AutoUpgradeRollback.PreviousAppImage)
+	assert.InDelta(t, 10*time.Minute, resp.Delay, float64(time.Minute))
+	cond := app.Status.Condition(v1.AppInstanceConditionRollback)
+	assert.True(t, cond.Success)
+	assert.Contains(t, cond.Message, "waiting until")
+}
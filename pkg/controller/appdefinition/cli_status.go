@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"strconv"
+	"time"
 
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/baaah/pkg/router"
@@ -13,6 +14,7 @@ func CLIStatus(req router.Request, resp router.Response) error {
 	app := req.Object.(*v1.AppInstance)
 	app.Status.Columns.UpToDate = uptodate(app)
 	app.Status.Columns.Healthy = healthy(app)
+	app.Status.Columns.Replicas = replicas(app)
 	app.Status.Columns.Message = message(app)
 	resp.Objects(app)
 	return nil
@@ -41,6 +43,11 @@ func message(app *v1.AppInstance) string {
 			return "Upgrade available: " + app.Status.ConfirmUpgradeAppImage
 		}
 
+		if app.Status.AvailableAppImage != "" && app.Status.NextMaintenanceWindow != nil {
+			return fmt.Sprintf("Upgrade available: %s, waiting for maintenance window at %s", app.Status.AvailableAppImage,
+				app.Status.NextMaintenanceWindow.UTC().Format(time.RFC3339))
+		}
+
 		if app.Status.Ready {
 			return "OK"
 		} else {
@@ -81,7 +88,11 @@ func healthy(app *v1.AppInstance) string {
 		ready, desired int32
 	)
 	for _, status := range app.Status.ContainerStatus {
-		desired += status.ReadyDesired
+		if status.DesiredReplicas > status.ReadyDesired {
+			desired += status.DesiredReplicas
+		} else {
+			desired += status.ReadyDesired
+		}
 		ready += status.Ready
 	}
 	if ready != desired {
@@ -89,3 +100,23 @@ func healthy(app *v1.AppInstance) string {
 	}
 	return strconv.Itoa(int(ready))
 }
+
+func replicas(app *v1.AppInstance) string {
+	if app.Status.Namespace == "" {
+		return "-"
+	}
+	if app.Status.Stopped {
+		return "-"
+	}
+	var (
+		current, desired int32
+	)
+	for _, status := range app.Status.ContainerStatus {
+		current += status.CurrentReplicas
+		desired += status.DesiredReplicas
+	}
+	if current != desired {
+		return fmt.Sprintf("%d/%d", current, desired)
+	}
+	return strconv.Itoa(int(current))
+}
@@ -95,6 +95,10 @@ func getDependencyAnnotations(app *v1.AppInstance, deps []v1.Dependency) map[str
 	if app.Generation > 0 {
 		result[labels.AcornAppGeneration] = strconv.Itoa(int(app.Generation))
 	}
+	// The digest tells which image of the app the object was rendered from
+	if app.Status.AppImage.Digest != "" {
+		result[labels.AcornAppImageDigest] = app.Status.AppImage.Digest
+	}
 	if len(deps) > 0 {
 		buf := &strings.Builder{}
 		for _, dep := range deps {
@@ -165,6 +169,28 @@ func (d *depCheckingResponse) isDepReady(depName string) (ready bool, found bool
 	return false, true
 }
 
+func (d *depCheckingResponse) isStatefulSetReady(depName string) (ready bool, found bool) {
+	var sts appsv1.StatefulSet
+	err := d.req.Get(&sts, d.app.Status.Namespace, depName)
+	if apierrors.IsNotFound(err) {
+		return false, false
+	}
+	if err != nil {
+		// if err just return it as not ready
+		return false, true
+	}
+
+	replicas := int32(1)
+	if sts.Spec.Replicas != nil {
+		replicas = *sts.Spec.Replicas
+	}
+
+	return sts.Annotations[labels.AcornAppGeneration] == strconv.Itoa(int(d.app.Generation)) &&
+		sts.Status.ObservedGeneration == sts.Generation &&
+		sts.Status.ReadyReplicas == replicas &&
+		sts.Status.UpdatedReplicas == replicas, true
+}
+
 type depCheck func(string) (bool, bool)
 
 func (d *depCheckingResponse) checkDeps(deps []string) bool {
@@ -175,7 +201,7 @@ outer:
 				return true
 			}
 		}
-		for _, depCheck := range []depCheck{d.isDepReady, d.isJobReady, d.isCronJobReady} {
+		for _, depCheck := range []depCheck{d.isDepReady, d.isStatefulSetReady, d.isJobReady, d.isCronJobReady} {
 			if ready, found := depCheck(depName); found && !ready {
 				return false
 			} else if found && ready {
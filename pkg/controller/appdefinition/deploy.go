@@ -27,6 +27,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -59,6 +60,15 @@ func DeploySpec(req router.Request, resp router.Response) (err error) {
 		return err
 	}
 
+	if err := addObjects(req, cfg, appInstance, tag, pullSecrets, resp); err != nil {
+		return err
+	}
+
+	resp.Objects(pullSecrets.Objects()...)
+	return pullSecrets.Err()
+}
+
+func addObjects(req router.Request, cfg *apiv1.Config, appInstance *v1.AppInstance, tag name.Reference, pullSecrets *PullSecrets, resp router.Response) error {
 	addNamespace(cfg, appInstance, resp)
 	if err := addDeployments(req, appInstance, tag, pullSecrets, resp); err != nil {
 		return err
@@ -78,12 +88,7 @@ func DeploySpec(req router.Request, resp router.Response) (err error) {
 	if err := addPVCs(req, appInstance, resp); err != nil {
 		return err
 	}
-	if err := addConfigMaps(appInstance, resp); err != nil {
-		return err
-	}
-
-	resp.Objects(pullSecrets.Objects()...)
-	return pullSecrets.Err()
+	return addConfigMaps(appInstance, resp)
 }
 
 func addDeployments(req router.Request, appInstance *v1.AppInstance, tag name.Reference, pullSecrets *PullSecrets, resp router.Response) error {
@@ -390,22 +395,58 @@ func toProbe(container v1.Container, probeType v1.ProbeType) *corev1.Probe {
 	return nil
 }
 
+func addComputeResource(resources *corev1.ResourceRequirements, name corev1.ResourceName, request, limit string) {
+	// Quantities are validated when the Acornfile is parsed and when the app is created, so invalid values are ignored here
+	if q, err := resource.ParseQuantity(request); err == nil {
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		resources.Requests[name] = q
+	}
+	if q, err := resource.ParseQuantity(limit); err == nil {
+		if resources.Limits == nil {
+			resources.Limits = corev1.ResourceList{}
+		}
+		resources.Limits[name] = q
+	}
+}
+
+func toComputeResource(containerName string, computeResource *v1.ComputeResource, bindings []v1.ResourceBinding) (string, string) {
+	if binding, ok := v1.FindResourceBinding(containerName, bindings); ok {
+		return binding.Request, binding.Limit
+	}
+	if computeResource == nil {
+		return "", ""
+	}
+	return computeResource.Request, computeResource.Limit
+}
+
+func toResources(app *v1.AppInstance, containerName string, container v1.Container) (result corev1.ResourceRequirements) {
+	request, limit := toComputeResource(containerName, container.Memory, app.Spec.Memory)
+	addComputeResource(&result, corev1.ResourceMemory, request, limit)
+	request, limit = toComputeResource(containerName, container.CPU, app.Spec.CPU)
+	addComputeResource(&result, corev1.ResourceCPU, request, limit)
+	return
+}
+
 func toContainer(app *v1.AppInstance, tag name.Reference, deploymentName, containerName string, container v1.Container) corev1.Container {
 	return corev1.Container{
-		Name:           containerName,
-		Image:          images.ResolveTag(tag, container.Image),
-		Command:        container.Entrypoint,
-		Args:           container.Command,
-		WorkingDir:     container.WorkingDir,
-		Env:            toEnv(app, container.Environment, app.Spec.Environment),
-		EnvFrom:        toEnvFrom(container.Environment),
-		TTY:            container.Interactive,
-		Stdin:          container.Interactive,
-		Ports:          toPorts(container),
-		VolumeMounts:   toMounts(app, deploymentName, containerName, container),
-		LivenessProbe:  toProbe(container, v1.LivenessProbeType),
-		StartupProbe:   toProbe(container, v1.StartupProbeType),
-		ReadinessProbe: toProbe(container, v1.ReadinessProbeType),
+		Name:            containerName,
+		Image:           images.ResolveTag(tag, container.Image),
+		Command:         container.Entrypoint,
+		Args:            container.Command,
+		WorkingDir:      container.WorkingDir,
+		Env:             toEnv(app, container.Environment, app.Spec.Environment),
+		EnvFrom:         toEnvFrom(container.Environment),
+		TTY:             container.Interactive,
+		Stdin:           container.Interactive,
+		Ports:           toPorts(container),
+		Resources:       toResources(app, containerName, container),
+		VolumeMounts:    toMounts(app, deploymentName, containerName, container),
+		LivenessProbe:   toProbe(container, v1.LivenessProbeType),
+		StartupProbe:    toProbe(container, v1.StartupProbeType),
+		ReadinessProbe:  toProbe(container, v1.ReadinessProbeType),
+		SecurityContext: toSecurityContext(container.SecurityContext),
 	}
 }
 
@@ -515,6 +556,10 @@ func getRevision(req router.Request, namespace, secretName string) (string, erro
 	}
 	hash := sha256.New()
 	for _, entry := range typed.Sorted(secret.Data) {
+		// Removing the previous value after a rotation doesn't need a redeploy
+		if isPreviousKey(secret.Type, entry.Key) {
+			continue
+		}
 		hash.Write([]byte(entry.Key))
 		hash.Write([]byte{'\x00'})
 		hash.Write(entry.Value)
@@ -565,7 +610,8 @@ func getSecretAnnotations(req router.Request, appInstance *v1.AppInstance, conta
 
 func toDeployment(req router.Request, appInstance *v1.AppInstance, tag name.Reference, name string, container v1.Container, pullSecrets *PullSecrets) (*appsv1.Deployment, error) {
 	var (
-		stateful = isStateful(appInstance, container)
+		// Containers that set stateful run as a StatefulSet and get a volume for each replica instead
+		stateful = isStateful(appInstance, container) && !container.Stateful
 	)
 
 	containers, initContainers := toContainers(appInstance, tag, name, container)
@@ -575,6 +621,12 @@ func toDeployment(req router.Request, appInstance *v1.AppInstance, tag name.Refe
 		return nil, err
 	}
 
+	seedContainers, err := toSeedContainers(req, appInstance, tag, name, container)
+	if err != nil {
+		return nil, err
+	}
+	initContainers = append(seedContainers, initContainers...)
+
 	volumes, err := toVolumes(appInstance, container)
 	if err != nil {
 		return nil, err
@@ -586,6 +638,7 @@ func toDeployment(req router.Request, appInstance *v1.AppInstance, tag name.Refe
 	maps.Copy(podLabels, ports.ToPodLabels(appInstance, name))
 
 	deploymentAnnotations := containerAnnotations(appInstance, container, name)
+	affinity, topologySpreadConstraints := toSpread(container, matchLabels)
 
 	dep := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -612,6 +665,10 @@ func toDeployment(req router.Request, appInstance *v1.AppInstance, tag name.Refe
 					InitContainers:                initContainers,
 					Volumes:                       volumes,
 					ServiceAccountName:            name,
+					NodeSelector:                  toNodeSelector(appInstance, name, container),
+					Tolerations:                   toTolerations(container),
+					Affinity:                      affinity,
+					TopologySpreadConstraints:     topologySpreadConstraints,
 				},
 			},
 		},
@@ -621,10 +678,15 @@ func toDeployment(req router.Request, appInstance *v1.AppInstance, tag name.Refe
 		dep.Spec.Replicas = &[]int32{1}[0]
 		dep.Spec.Template.Spec.Hostname = dep.Name
 		dep.Spec.Strategy.Type = appsv1.RecreateDeploymentStrategyType
+	} else if isAutoscaled(appInstance, container) {
+		// The HorizontalPodAutoscaler owns the replica count
+		dep.Spec.Replicas = nil
 	} else if dep.Spec.Replicas == nil || *dep.Spec.Replicas == 1 {
 		dep.Spec.Template.Spec.Hostname = dep.Name
 	}
 
+	setRollout(dep, container)
+
 	if appInstance.Spec.Stop != nil && *appInstance.Spec.Stop {
 		dep.Spec.Replicas = new(int32)
 	}
@@ -645,6 +707,20 @@ func ToDeployments(req router.Request, appInstance *v1.AppInstance, tag name.Ref
 		if perms := v1.FindPermission(dep.GetName(), appInstance.Spec.Permissions); perms.HasRules() {
 			result = append(result, toPermissions(perms, dep.GetLabels(), dep.GetAnnotations(), appInstance)...)
 		}
+		if isAutoscaled(appInstance, entry.Value) {
+			result = append(result, toHorizontalPodAutoscaler(appInstance, dep, entry.Value))
+		}
+		if entry.Value.Disruption != nil {
+			result = append(result, toPodDisruptionBudget(appInstance, dep, entry.Value))
+		}
+		if entry.Value.Stateful {
+			sts, err := toStatefulSet(req, appInstance, dep, entry.Value)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, toHeadlessService(appInstance, dep, entry.Value), sts, sa)
+			continue
+		}
 		result = append(result, dep, sa)
 	}
 	return result, nil
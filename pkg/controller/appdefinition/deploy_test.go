@@ -12,8 +12,12 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -158,6 +162,450 @@ func TestSidecar(t *testing.T) {
 	assert.Equal(t, "sidecar2", dep.Spec.Template.Spec.Containers[1].Image)
 }
 
+func TestComputeResources(t *testing.T) {
+	dep := ToDeploymentsTest(t, &v1.AppInstance{
+		Spec: v1.AppInstanceSpec{
+			Memory: []v1.ResourceBinding{
+				{
+					Target:  "right",
+					Request: "1Gi",
+					Limit:   "1Gi",
+				},
+			},
+			CPU: []v1.ResourceBinding{
+				{
+					Request: "100m",
+					Limit:   "1",
+				},
+			},
+		},
+		Status: v1.AppInstanceStatus{
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"test": {
+						Memory: &v1.ComputeResource{
+							Request: "256Mi",
+							Limit:   "512Mi",
+						},
+						Sidecars: map[string]v1.Container{
+							"right": {
+								Memory: &v1.ComputeResource{
+									Request: "128Mi",
+									Limit:   "128Mi",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, testTag, nil)[0].(*appsv1.Deployment)
+	assert.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+			corev1.ResourceCPU:    resource.MustParse("1"),
+		},
+	}, dep.Spec.Template.Spec.Containers[0].Resources)
+	assert.Equal(t, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+			corev1.ResourceCPU:    resource.MustParse("1"),
+		},
+	}, dep.Spec.Template.Spec.Containers[1].Resources)
+}
+
+func TestAutoscale(t *testing.T) {
+	objs := ToDeploymentsTest(t, &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app",
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-namespace",
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"test": {
+						Scale: &[]int32{3}[0],
+						Autoscale: &v1.Autoscale{
+							Min:       2,
+							Max:       5,
+							TargetCPU: 75,
+						},
+					},
+				},
+			},
+		},
+	}, testTag, nil)
+
+	var (
+		dep *appsv1.Deployment
+		hpa *autoscalingv2.HorizontalPodAutoscaler
+	)
+	for _, obj := range objs {
+		switch v := obj.(type) {
+		case *appsv1.Deployment:
+			dep = v
+		case *autoscalingv2.HorizontalPodAutoscaler:
+			hpa = v
+		}
+	}
+
+	assert.NotNil(t, dep)
+	assert.Nil(t, dep.Spec.Replicas)
+	assert.NotNil(t, hpa)
+	assert.Equal(t, "test", hpa.Name)
+	assert.Equal(t, "app-namespace", hpa.Namespace)
+	assert.Equal(t, "Deployment", hpa.Spec.ScaleTargetRef.Kind)
+	assert.Equal(t, "test", hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	assert.Len(t, hpa.Spec.Metrics, 1)
+	assert.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+	assert.Equal(t, int32(75), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+}
+
+func TestSecurityContext(t *testing.T) {
+	dep := ToDeploymentsTest(t, &v1.AppInstance{
+		Status: v1.AppInstanceStatus{
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"test": {
+						SecurityContext: &v1.SecurityContext{
+							RunAsUser:                &[]int64{1000}[0],
+							RunAsNonRoot:             &[]bool{true}[0],
+							AllowPrivilegeEscalation: &[]bool{false}[0],
+							Capabilities: &v1.Capabilities{
+								Add:  []string{"NET_BIND_SERVICE"},
+								Drop: []string{"ALL"},
+							},
+							SeccompProfile: "RuntimeDefault",
+						},
+						Sidecars: map[string]v1.Container{
+							"side": {},
+						},
+					},
+				},
+			},
+		},
+	}, testTag, nil)[0].(*appsv1.Deployment)
+	assert.Equal(t, &corev1.SecurityContext{
+		RunAsUser:                &[]int64{1000}[0],
+		RunAsNonRoot:             &[]bool{true}[0],
+		AllowPrivilegeEscalation: &[]bool{false}[0],
+		Capabilities: &corev1.Capabilities{
+			Add:  []corev1.Capability{"NET_BIND_SERVICE"},
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}, dep.Spec.Template.Spec.Containers[0].SecurityContext)
+	assert.Nil(t, dep.Spec.Template.Spec.Containers[1].SecurityContext)
+}
+
+func TestRolloutAndDisruption(t *testing.T) {
+	objs := ToDeploymentsTest(t, &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app",
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-namespace",
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"test": {
+						Scale: &[]int32{3}[0],
+						Rollout: &v1.Rollout{
+							MaxSurge:        &[]intstr.IntOrString{intstr.FromInt(1)}[0],
+							MaxUnavailable:  &[]intstr.IntOrString{intstr.FromString("25%")}[0],
+							MinReadySeconds: 10,
+						},
+						Disruption: &v1.Disruption{
+							MinAvailable: &[]intstr.IntOrString{intstr.FromInt(2)}[0],
+						},
+					},
+				},
+			},
+		},
+	}, testTag, nil)
+
+	var (
+		dep *appsv1.Deployment
+		pdb *policyv1.PodDisruptionBudget
+	)
+	for _, obj := range objs {
+		switch v := obj.(type) {
+		case *appsv1.Deployment:
+			dep = v
+		case *policyv1.PodDisruptionBudget:
+			pdb = v
+		}
+	}
+
+	if assert.NotNil(t, dep) {
+		assert.Equal(t, int32(10), dep.Spec.MinReadySeconds)
+		assert.Equal(t, appsv1.RollingUpdateDeploymentStrategyType, dep.Spec.Strategy.Type)
+		assert.Equal(t, intstr.FromInt(1), *dep.Spec.Strategy.RollingUpdate.MaxSurge)
+		assert.Equal(t, intstr.FromString("25%"), *dep.Spec.Strategy.RollingUpdate.MaxUnavailable)
+	}
+	if assert.NotNil(t, pdb) {
+		assert.Equal(t, "test", pdb.Name)
+		assert.Equal(t, "app-namespace", pdb.Namespace)
+		assert.Equal(t, dep.Spec.Selector, pdb.Spec.Selector)
+		assert.Equal(t, intstr.FromInt(2), *pdb.Spec.MinAvailable)
+		assert.Nil(t, pdb.Spec.MaxUnavailable)
+	}
+}
+
+func TestRolloutStateful(t *testing.T) {
+	dep := ToDeploymentsTest(t, &v1.AppInstance{
+		Status: v1.AppInstanceStatus{
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"test": {
+						Dirs: map[string]v1.VolumeMount{
+							"/var/lib/data": {Volume: "data"},
+						},
+						Rollout: &v1.Rollout{
+							MaxSurge:        &[]intstr.IntOrString{intstr.FromInt(1)}[0],
+							MinReadySeconds: 5,
+						},
+					},
+				},
+				Volumes: map[string]v1.VolumeRequest{
+					"data": {},
+				},
+			},
+		},
+	}, testTag, nil)[0].(*appsv1.Deployment)
+	assert.Equal(t, int32(5), dep.Spec.MinReadySeconds)
+	assert.Equal(t, appsv1.RecreateDeploymentStrategyType, dep.Spec.Strategy.Type)
+	assert.Nil(t, dep.Spec.Strategy.RollingUpdate)
+}
+
+func TestRolloutStatefulSet(t *testing.T) {
+	objs := ToDeploymentsTest(t, &v1.AppInstance{
+		Status: v1.AppInstanceStatus{
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"db": {
+						Stateful: true,
+						Scale:    &[]int32{3}[0],
+						Dirs: map[string]v1.VolumeMount{
+							"/var/lib/data": {Volume: "data"},
+						},
+						Rollout: &v1.Rollout{
+							MaxUnavailable:  &[]intstr.IntOrString{intstr.FromInt(2)}[0],
+							MinReadySeconds: 5,
+						},
+					},
+				},
+				Volumes: map[string]v1.VolumeRequest{
+					"data": {},
+				},
+			},
+		},
+	}, testTag, nil)
+
+	var sts *appsv1.StatefulSet
+	for _, obj := range objs {
+		if v, ok := obj.(*appsv1.StatefulSet); ok {
+			sts = v
+		}
+	}
+
+	if assert.NotNil(t, sts) {
+		assert.Equal(t, int32(5), sts.Spec.MinReadySeconds)
+		assert.Equal(t, appsv1.RollingUpdateStatefulSetStrategyType, sts.Spec.UpdateStrategy.Type)
+		assert.Equal(t, intstr.FromInt(2), *sts.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable)
+	}
+}
+
+func TestStateful(t *testing.T) {
+	objs := ToDeploymentsTest(t, &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app",
+		},
+		Spec: v1.AppInstanceSpec{
+			Volumes: []v1.VolumeBinding{
+				{Target: "data", Class: "fast"},
+			},
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-namespace",
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"db": {
+						Stateful: true,
+						Scale:    &[]int32{3}[0],
+						Dirs: map[string]v1.VolumeMount{
+							"/var/lib/data": {Volume: "data"},
+							"/tmp":          {Volume: "scratch"},
+						},
+						Autoscale: &v1.Autoscale{
+							Min: 3,
+							Max: 5,
+						},
+					},
+				},
+				Volumes: map[string]v1.VolumeRequest{
+					"data": {
+						Size: "10G",
+					},
+					"scratch": {
+						Class: v1.VolumeRequestTypeEphemeral,
+					},
+				},
+			},
+		},
+	}, testTag, nil)
+
+	var (
+		sts *appsv1.StatefulSet
+		svc *corev1.Service
+		hpa *autoscalingv2.HorizontalPodAutoscaler
+	)
+	for _, obj := range objs {
+		switch v := obj.(type) {
+		case *appsv1.Deployment:
+			t.Fatal("stateful container should not have a deployment")
+		case *appsv1.StatefulSet:
+			sts = v
+		case *corev1.Service:
+			svc = v
+		case *autoscalingv2.HorizontalPodAutoscaler:
+			hpa = v
+		}
+	}
+
+	if assert.NotNil(t, svc) {
+		assert.Equal(t, "db-headless", svc.Name)
+		assert.Equal(t, corev1.ClusterIPNone, svc.Spec.ClusterIP)
+		assert.True(t, svc.Spec.PublishNotReadyAddresses)
+	}
+	if assert.NotNil(t, sts) {
+		assert.Equal(t, "db", sts.Name)
+		assert.Equal(t, "db-headless", sts.Spec.ServiceName)
+		assert.Nil(t, sts.Spec.Replicas)
+		assert.Equal(t, svc.Spec.Selector, sts.Spec.Selector.MatchLabels)
+		assert.Equal(t, "", sts.Spec.Template.Spec.Hostname)
+
+		assert.Len(t, sts.Spec.VolumeClaimTemplates, 1)
+		claim := sts.Spec.VolumeClaimTemplates[0]
+		assert.Equal(t, "data", claim.Name)
+		assert.Equal(t, "fast", *claim.Spec.StorageClassName)
+		assert.Equal(t, resource.MustParse("10G"), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+
+		var volumes []string
+		for _, volume := range sts.Spec.Template.Spec.Volumes {
+			volumes = append(volumes, volume.Name)
+		}
+		assert.Contains(t, volumes, "scratch")
+		assert.NotContains(t, volumes, "data")
+	}
+	if assert.NotNil(t, hpa) {
+		assert.Equal(t, "StatefulSet", hpa.Spec.ScaleTargetRef.Kind)
+	}
+}
+
+func TestStatefulSeed(t *testing.T) {
+	objs := ToDeploymentsTest(t, &v1.AppInstance{
+		Status: v1.AppInstanceStatus{
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"db": {
+						Stateful: true,
+						Dirs: map[string]v1.VolumeMount{
+							"/var/lib/data": {Volume: "data", SeedFrom: "./data"},
+						},
+						SecurityContext: &v1.SecurityContext{
+							RunAsNonRoot: &[]bool{true}[0],
+						},
+					},
+				},
+				Volumes: map[string]v1.VolumeRequest{
+					"data": {},
+				},
+			},
+		},
+	}, testTag, nil)
+
+	for _, obj := range objs {
+		if sts, ok := obj.(*appsv1.StatefulSet); ok {
+			initContainers := sts.Spec.Template.Spec.InitContainers
+			if assert.Len(t, initContainers, 1) {
+				assert.Equal(t, []string{"cp", "-a", "-n", "/var/lib/data/.", SeedPath + "/"}, initContainers[0].Command)
+				assert.Equal(t, "data", initContainers[0].VolumeMounts[0].Name)
+				assert.Equal(t, sts.Spec.Template.Spec.Containers[0].SecurityContext, initContainers[0].SecurityContext)
+				assert.NotNil(t, initContainers[0].SecurityContext)
+			}
+			return
+		}
+	}
+	t.Fatal("missing StatefulSet")
+}
+
+func TestPlacement(t *testing.T) {
+	objs := ToDeploymentsTest(t, &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app",
+		},
+		Spec: v1.AppInstanceSpec{
+			NodeSelectors: []v1.NodeSelectorBinding{
+				{Target: "test", Key: "kubernetes.io/arch", Value: "arm64"},
+				{Key: "kubernetes.io/arch", Value: "amd64"},
+				{Key: "pool", Value: "general"},
+				{Target: "other", Key: "pool", Value: "other"},
+			},
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-namespace",
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"test": {
+						NodeSelector: map[string]string{
+							"pool": "gpu",
+							"disk": "ssd",
+						},
+						Tolerations: []v1.Toleration{
+							{Key: "gpu", Operator: "Exists", Effect: "NoSchedule"},
+						},
+						Spread: v1.SpreadZone,
+					},
+				},
+			},
+		},
+	}, testTag, nil)
+
+	dep := objs[0].(*appsv1.Deployment)
+	podSpec := dep.Spec.Template.Spec
+	assert.Equal(t, map[string]string{
+		"kubernetes.io/arch": "arm64",
+		"pool":               "general",
+		"disk":               "ssd",
+	}, podSpec.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{
+		{Key: "gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	}, podSpec.Tolerations)
+	if assert.Len(t, podSpec.TopologySpreadConstraints, 1) {
+		assert.Equal(t, corev1.LabelTopologyZone, podSpec.TopologySpreadConstraints[0].TopologyKey)
+		assert.Equal(t, corev1.ScheduleAnyway, podSpec.TopologySpreadConstraints[0].WhenUnsatisfiable)
+		assert.Equal(t, dep.Spec.Selector, podSpec.TopologySpreadConstraints[0].LabelSelector)
+	}
+	if assert.NotNil(t, podSpec.Affinity) {
+		terms := podSpec.Affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+		if assert.Len(t, terms, 1) {
+			assert.Equal(t, corev1.LabelTopologyZone, terms[0].PodAffinityTerm.TopologyKey)
+		}
+	}
+}
+
 func TestPorts(t *testing.T) {
 	dep := ToDeploymentsTest(t, &v1.AppInstance{
 		Status: v1.AppInstanceStatus{
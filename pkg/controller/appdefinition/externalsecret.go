@@ -0,0 +1,110 @@
+package appdefinition
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/vault"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/rancher/wrangler/pkg/data/convert"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultRefreshInterval = time.Hour
+	// refreshRetry is how long to wait before reading an external secret again after reading it failed
+	refreshRetry = time.Minute
+)
+
+var readVault = vault.Read
+
+func refreshInterval(secretRef v1.Secret) (time.Duration, error) {
+	refresh := convert.ToString(secretRef.Params["refresh"])
+	if refresh == "" {
+		return defaultRefreshInterval, nil
+	}
+	d, err := time.ParseDuration(refresh)
+	if err != nil {
+		return 0, fmt.Errorf("invalid refresh %s: %w", refresh, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid refresh %s: must be greater than zero", refresh)
+	}
+	return d, nil
+}
+
+func lastRefresh(secret *corev1.Secret) time.Time {
+	t, _ := time.Parse(time.RFC3339, secret.Annotations[labels.AcornSecretRefreshedAt])
+	return t
+}
+
+// generateExternal reads the values of an external secret from its store. The values are read again once the refresh
+// interval has passed. If they can't be read again the values from the last read are kept.
+func generateExternal(req router.Request, appInstance *v1.AppInstance, secretName string, secretRef v1.Secret, existing *corev1.Secret, now time.Time) (*corev1.Secret, error) {
+	provider := convert.ToString(secretRef.Params["provider"])
+	if provider != "" && provider != "vault" {
+		return nil, fmt.Errorf("unsupported external secret provider %s", provider)
+	}
+	path := convert.ToString(secretRef.Params["path"])
+	if path == "" {
+		return nil, fmt.Errorf("path is required for external secrets")
+	}
+	interval, err := refreshInterval(secretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: secretName + "-",
+			Namespace:    appInstance.Namespace,
+			Labels:       labelsForSecret(secretName, appInstance, secretRef),
+			Annotations:  annotationsForSecret(secretName, appInstance, secretRef),
+		},
+		Type: v1.SecretTypeExternal,
+	}
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+
+	if existing != nil && now.Before(lastRefresh(existing).Add(interval)) {
+		secret.Data = existing.Data
+		secret.Annotations[labels.AcornSecretRefreshedAt] = existing.Annotations[labels.AcornSecretRefreshedAt]
+		return updateOrCreate(req, existing, secret)
+	}
+
+	data, err := readVault(req.Ctx, req.Client, appInstance.Namespace, path)
+	if err != nil {
+		if existing == nil || existing.Annotations[labels.AcornSecretRefreshedAt] == "" {
+			return nil, err
+		}
+		logrus.Errorf("Failed to refresh external secret %s of app %s/%s, keeping the previous values: %v", secretName,
+			appInstance.Namespace, appInstance.Name, err)
+		return existing, nil
+	}
+
+	secret.Data = data
+	secret.Annotations[labels.AcornSecretRefreshedAt] = now.UTC().Format(time.RFC3339)
+	return updateOrCreate(req, existing, secret)
+}
+
+// nextRefresh returns when the values of an external secret have to be read again
+func nextRefresh(secretRef v1.Secret, secret *corev1.Secret) (time.Time, bool) {
+	last := lastRefresh(secret)
+	if last.IsZero() {
+		return time.Time{}, false
+	}
+	interval, err := refreshInterval(secretRef)
+	if err != nil {
+		return time.Time{}, false
+	}
+	next := last.Add(interval)
+	if retry := timeNow().Add(refreshRetry); next.Before(retry) {
+		next = retry
+	}
+	return next, true
+}
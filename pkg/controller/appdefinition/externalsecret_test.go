@@ -0,0 +1,68 @@
+package appdefinition
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// stubVault replaces the Vault client for the test, counting the reads
+func stubVault(t *testing.T, data map[string][]byte, err error) *int {
+	t.Helper()
+
+	reads := 0
+	old := readVault
+	t.Cleanup(func() { readVault = old })
+	readVault = func(_ context.Context, _ kclient.Reader, namespace, path string) (map[string][]byte, error) {
+		assert.Equal(t, "app-ns", namespace)
+		assert.Equal(t, "secret/data/db", path)
+		reads++
+		return data, err
+	}
+	return &reads
+}
+
+func TestExternal(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2022, 1, 1, 1, 0, 0, 0, time.UTC)
+	}
+	defer func() {
+		timeNow = time.Now
+	}()
+
+	// The existing secrets in testdata were refreshed 5 minutes or an hour ago, the refresh interval is 10 minutes
+	for _, test := range []struct {
+		dir   string
+		data  map[string][]byte
+		err   error
+		reads int
+		delay time.Duration
+	}{
+		{dir: "generate", data: map[string][]byte{"password": []byte("s3cret")}, reads: 1, delay: 10 * time.Minute},
+		{dir: "cached", reads: 0, delay: 5 * time.Minute},
+		{dir: "refresh", data: map[string][]byte{"password": []byte("rotated")}, reads: 1, delay: 10 * time.Minute},
+		// The previous values are kept when they can't be read again, and read again shortly after
+		{dir: "refresh-failed", err: errors.New("permission denied"), reads: 1, delay: refreshRetry},
+		{dir: "failed", err: errors.New("permission denied"), reads: 1},
+	} {
+		path := filepath.Join("testdata/secret-external", test.dir)
+		t.Run(path, func(t *testing.T) {
+			reads := stubVault(t, test.data, test.err)
+
+			harness, input, err := tester.FromDir(scheme.Scheme, path)
+			require.NoError(t, err)
+			harness.ExpectedDelay = test.delay
+			_, err = harness.InvokeFunc(t, input, CreateSecrets)
+			require.NoError(t, err)
+			assert.Equal(t, test.reads, *reads)
+		})
+	}
+}
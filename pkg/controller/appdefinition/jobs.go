@@ -54,6 +54,12 @@ func toJob(req router.Request, appInstance *v1.AppInstance, pullSecrets *PullSec
 		return nil, err
 	}
 
+	seedContainers, err := toSeedContainers(req, appInstance, tag, name, container)
+	if err != nil {
+		return nil, err
+	}
+	initContainers = append(seedContainers, initContainers...)
+
 	baseAnnotations := labels.Merge(secretAnnotations, labels.GatherScoped(name, v1.LabelTypeJob,
 		appInstance.Status.AppSpec.Annotations, container.Annotations, appInstance.Spec.Annotations))
 
@@ -80,6 +86,8 @@ func toJob(req router.Request, appInstance *v1.AppInstance, pullSecrets *PullSec
 				InitContainers:                setTerminationPath(initContainers),
 				Volumes:                       volumes,
 				ServiceAccountName:            name,
+				NodeSelector:                  toNodeSelector(appInstance, name, container),
+				Tolerations:                   toTolerations(container),
 			},
 		},
 	}
@@ -0,0 +1,169 @@
+package appdefinition
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/rancher/wrangler/pkg/data/convert"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// generateKeySecret generates the private key of an ssh-key or keypair secret once. The public key is derived from the
+// private key, so a private key supplied by the user gets a matching public key.
+func generateKeySecret(req router.Request, appInstance *v1.AppInstance, secretName string, secretRef v1.Secret, existing *corev1.Secret) (*corev1.Secret, error) {
+	var (
+		secretType                    = v1.SecretTypeKeyPair
+		privateKeyName, publicKeyName = v1.KeyPairPrivateKey, v1.KeyPairPublicKey
+		encodePrivate                 = encodePKCS8PrivateKey
+		encodePublic                  = encodePKIXPublicKey
+	)
+	if secretRef.Type == "ssh-key" {
+		secretType = v1.SecretTypeSSHKey
+		privateKeyName, publicKeyName = v1.SSHKeyPrivateKey, v1.SSHKeyPublicKey
+		encodePrivate = encodeSSHPrivateKey
+		encodePublic = encodeSSHPublicKey
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: secretName + "-",
+			Namespace:    appInstance.Namespace,
+			Labels:       labelsForSecret(secretName, appInstance, secretRef),
+			Annotations:  annotationsForSecret(secretName, appInstance, secretRef),
+		},
+		Data: seedData(existing, secretRef.Data, privateKeyName, publicKeyName),
+		Type: secretType,
+	}
+
+	if len(secret.Data[privateKeyName]) == 0 {
+		key, err := generatePrivateKey(convert.ToString(secretRef.Params["algorithm"]))
+		if err != nil {
+			return nil, err
+		}
+		secret.Data[privateKeyName], err = encodePrivate(key)
+		if err != nil {
+			return nil, err
+		}
+		secret.Data[publicKeyName] = nil
+	}
+
+	if len(secret.Data[publicKeyName]) == 0 {
+		key, err := ssh.ParseRawPrivateKey(secret.Data[privateKeyName])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", privateKeyName, err)
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		secret.Data[publicKeyName], err = encodePublic(signer.Public())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return updateOrCreate(req, existing, secret)
+}
+
+func encodePKCS8PrivateKey(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+func encodePKIXPublicKey(key crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+func encodeSSHPublicKey(key crypto.PublicKey) ([]byte, error) {
+	sshKey, err := ssh.NewPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return ssh.MarshalAuthorizedKey(sshKey), nil
+}
+
+// encodeSSHPrivateKey encodes a private key in a format that OpenSSH reads. RSA and ECDSA keys use their traditional PEM
+// encoding. Ed25519 keys can only be read in the OpenSSH format.
+func encodeSSHPrivateKey(key crypto.Signer) ([]byte, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}), nil
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+	case ed25519.PrivateKey:
+		return encodeOpenSSHEd25519PrivateKey(k)
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// encodeOpenSSHEd25519PrivateKey encodes an unencrypted key in the openssh-key-v1 format described in
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.key
+func encodeOpenSSHEd25519PrivateKey(key ed25519.PrivateKey) ([]byte, error) {
+	publicKey, err := ssh.NewPublicKey(key.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	check := make([]byte, 4)
+	if _, err := rand.Read(check); err != nil {
+		return nil, err
+	}
+
+	private := ssh.Marshal(struct {
+		Check1  uint32
+		Check2  uint32
+		KeyType string
+		Public  []byte
+		Private []byte
+		Comment string
+	}{
+		Check1:  binary.BigEndian.Uint32(check),
+		Check2:  binary.BigEndian.Uint32(check),
+		KeyType: ssh.KeyAlgoED25519,
+		Public:  key.Public().(ed25519.PublicKey),
+		Private: key,
+	})
+	for i := 1; len(private)%8 != 0; i++ {
+		private = append(private, byte(i))
+	}
+
+	data := append([]byte("openssh-key-v1\x00"), ssh.Marshal(struct {
+		CipherName  string
+		KDFName     string
+		KDFOptions  string
+		NumKeys     uint32
+		PublicKey   []byte
+		PrivateKeys []byte
+	}{
+		CipherName:  "none",
+		KDFName:     "none",
+		NumKeys:     1,
+		PublicKey:   publicKey.Marshal(),
+		PrivateKeys: private,
+	})...)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: data}), nil
+}
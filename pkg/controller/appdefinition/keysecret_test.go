@@ -0,0 +1,52 @@
+package appdefinition
+
+import (
+	"testing"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestKeySecretExisting(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/secret-key/existing", CreateSecrets)
+}
+
+func TestKeySecretPrivateKey(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/secret-key/private-key", CreateSecrets)
+}
+
+// The generated keys are random, so they are checked instead of compared to testdata
+func TestKeySecret_Gen(t *testing.T) {
+	resp := createSecretsFromDir(t, "testdata/secret-key/generate")
+	assert.Len(t, resp.Client.Created, 6)
+
+	for _, obj := range resp.Client.Created {
+		secret := obj.(*corev1.Secret)
+		name := secret.Labels[labels.AcornSecretName]
+
+		t.Run(name, func(t *testing.T) {
+			switch secret.Type {
+			case v1.SecretTypeSSHKey:
+				signer, err := ssh.ParsePrivateKey(secret.Data[v1.SSHKeyPrivateKey])
+				require.NoError(t, err)
+				assert.Equal(t, string(ssh.MarshalAuthorizedKey(signer.PublicKey())), string(secret.Data[v1.SSHKeyPublicKey]))
+			case v1.SecretTypeKeyPair:
+				key, err := parsePrivateKey(secret.Data[v1.KeyPairPrivateKey])
+				require.NoError(t, err)
+				assert.Equal(t, name, "keypair-"+keyAlgorithm(key))
+
+				public, err := encodePKIXPublicKey(key.Public())
+				require.NoError(t, err)
+				assert.Equal(t, string(public), string(secret.Data[v1.KeyPairPublicKey]))
+			default:
+				t.Fatalf("unexpected secret type %s", secret.Type)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package appdefinition
+
+import (
+	"fmt"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/autoupgrade"
+	"github.com/acorn-io/acorn/pkg/autoupgrade/validate"
+	"github.com/acorn-io/acorn/pkg/config"
+	"github.com/acorn-io/baaah/pkg/router"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maintenanceWindow returns the window automatic upgrades of the app are restricted to. The app's own
+// window takes precedence over the cluster default. A nil window means upgrades can happen at any time.
+func maintenanceWindow(req router.Request, appInstance *v1.AppInstance) (*validate.Window, error) {
+	window := appInstance.Spec.AutoUpgradeMaintenanceWindow
+	if window == "" {
+		cfg, err := config.Get(req.Ctx, req.Client)
+		if err != nil {
+			return nil, err
+		}
+		window = *cfg.AutoUpgradeMaintenanceWindow
+	}
+	if window == "" {
+		return nil, nil
+	}
+	return validate.MaintenanceWindow(window)
+}
+
+// waitForMaintenanceWindow returns a message and true if targetImage is an automatic upgrade that has to wait
+// for the next maintenance window. Upgrades confirmed by a user and manual updates are never delayed.
+func waitForMaintenanceWindow(req router.Request, resp router.Response, appInstance *v1.AppInstance, targetImage string) (string, bool, error) {
+	mode, _ := autoupgrade.Mode(appInstance.Spec)
+	if mode != "enabled" || appInstance.Status.AppImage.Name == "" ||
+		targetImage != appInstance.Status.AvailableAppImage {
+		return "", false, nil
+	}
+
+	window, err := maintenanceWindow(req, appInstance)
+	if err != nil || window == nil {
+		return "", false, err
+	}
+
+	now := timeNow()
+	if window.IsOpen(now) {
+		return "", false, nil
+	}
+
+	next := metav1.NewTime(window.Next(now))
+	appInstance.Status.NextMaintenanceWindow = &next
+	resp.RetryAfter(next.Sub(now))
+	return fmt.Sprintf("upgrade to %s is waiting for the maintenance window at %s", targetImage,
+		next.UTC().Format(time.RFC3339)), true, nil
+}
@@ -0,0 +1,50 @@
+package appdefinition
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+)
+
+// unavailableRegistry fails every pull, upgrades that are not held for a maintenance window report its error
+type unavailableRegistry struct{}
+
+func (unavailableRegistry) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("registry unavailable")
+}
+
+func TestMaintenanceWindow(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	defer func() {
+		timeNow = time.Now
+	}()
+
+	// The windows in testdata open at noon, so held upgrades are retried after twelve hours
+	for dir, delay := range map[string]time.Duration{
+		"open":                          0,
+		"closed":                        12 * time.Hour,
+		"cluster-default":               12 * time.Hour,
+		"app-overrides-cluster-default": 0,
+		"notify-upgrade":                0,
+		"first-pull":                    0,
+	} {
+		path := filepath.Join("testdata/maintenancewindow", dir)
+		t.Run(path, func(t *testing.T) {
+			harness, input, err := tester.FromDir(scheme.Scheme, path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			harness.ExpectedDelay = delay
+			if _, err := harness.Invoke(t, input, PullAppImage(unavailableRegistry{})); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package appdefinition
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/config"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/acorn-io/baaah/pkg/typed"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// placeholderKeys are the keys that the secrets of each type get when they are generated
+var placeholderKeys = map[string][]string{
+	"basic":     {corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey},
+	"token":     {"token"},
+	"generated": {"content"},
+	"tls":       {corev1.TLSCertKey, corev1.TLSPrivateKeyKey},
+	"ssh-key":   {v1.SSHKeyPrivateKey, v1.SSHKeyPublicKey},
+	"keypair":   {v1.KeyPairPrivateKey, v1.KeyPairPublicKey},
+}
+
+// Manifests returns the objects DeploySpec would create for the appInstance without talking to a cluster.
+// Everything the controller would normally look up (volumes, linked services, config) is treated as not
+// existing, so the result is what a fresh install into an empty cluster would look like. The secrets of the
+// app are included as placeholders, with the values set in the Acornfile and empty values for the rest.
+func Manifests(ctx context.Context, appInstance *v1.AppInstance) ([]kclient.Object, error) {
+	secrets := placeholderSecrets(appInstance)
+	c := emptyClient{
+		secrets: map[kclient.ObjectKey]*corev1.Secret{},
+	}
+	for _, secret := range secrets {
+		c.secrets[kclient.ObjectKeyFromObject(secret)] = secret
+	}
+
+	req := router.Request{
+		Client:    c,
+		Object:    appInstance,
+		Ctx:       ctx,
+		Namespace: appInstance.Namespace,
+		Name:      appInstance.Name,
+	}
+
+	tag, err := name.ParseReference(appInstance.Spec.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Get(ctx, req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &collectingResponse{}
+	if err := addObjects(req, cfg, appInstance, tag, nil, resp); err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		resp.Objects(secret)
+	}
+	return resp.objects, nil
+}
+
+// placeholderSecrets returns the secrets of the app in its namespace, with the keys that the secrets get when
+// they are generated and the keys that the containers and jobs reference
+func placeholderSecrets(appInstance *v1.AppInstance) []*corev1.Secret {
+	referenced := map[string][]string{}
+	addReferences := func(container v1.Container) {
+		for _, env := range container.Environment {
+			if env.Secret.Name != "" && env.Secret.Key != "" {
+				referenced[env.Secret.Name] = append(referenced[env.Secret.Name], env.Secret.Key)
+			}
+		}
+		for _, file := range container.Files {
+			if file.Secret.Name != "" && file.Secret.Key != "" {
+				referenced[file.Secret.Name] = append(referenced[file.Secret.Name], file.Secret.Key)
+			}
+		}
+	}
+	for _, containers := range []map[string]v1.Container{appInstance.Status.AppSpec.Containers, appInstance.Status.AppSpec.Jobs} {
+		for _, container := range containers {
+			addReferences(container)
+			for _, sidecar := range container.Sidecars {
+				addReferences(sidecar)
+			}
+		}
+	}
+
+	var result []*corev1.Secret
+	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Secrets) {
+		data := map[string][]byte{}
+		for _, key := range append(placeholderKeys[entry.Value.Type], referenced[entry.Key]...) {
+			data[key] = []byte{}
+		}
+		for key, value := range entry.Value.Data {
+			data[key] = []byte(value)
+		}
+		result = append(result, toAppSecret(appInstance, entry.Key, entry.Value, data,
+			corev1.SecretType(v1.SecretTypePrefix+entry.Value.Type)))
+	}
+	return result
+}
+
+type collectingResponse struct {
+	objects []kclient.Object
+}
+
+func (c *collectingResponse) RetryAfter(time.Duration) {}
+
+func (c *collectingResponse) Objects(objs ...kclient.Object) {
+	c.objects = append(c.objects, objs...)
+}
+
+// emptyClient is a read only client for a cluster that has no objects in it other than the placeholder secrets
+type emptyClient struct {
+	kclient.Client
+	secrets map[kclient.ObjectKey]*corev1.Secret
+}
+
+func (e emptyClient) Get(_ context.Context, key kclient.ObjectKey, obj kclient.Object) error {
+	if secret, ok := obj.(*corev1.Secret); ok && e.secrets[key] != nil {
+		e.secrets[key].DeepCopyInto(secret)
+		return nil
+	}
+	return apierror.NewNotFound(schema.GroupResource{}, key.Name)
+}
+
+func (emptyClient) List(context.Context, kclient.ObjectList, ...kclient.ListOption) error {
+	return nil
+}
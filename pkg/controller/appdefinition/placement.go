@@ -0,0 +1,84 @@
+package appdefinition
+
+import (
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var spreadTopologyKeys = map[v1.Spread]string{
+	v1.SpreadZone: corev1.LabelTopologyZone,
+	v1.SpreadNode: corev1.LabelHostname,
+}
+
+// toNodeSelector merges the node selector of the Acornfile with the node selectors bound at runtime. Bindings
+// that target the container by name take precedence over bindings with no target.
+func toNodeSelector(appInstance *v1.AppInstance, name string, container v1.Container) map[string]string {
+	result := map[string]string{}
+	for key, value := range container.NodeSelector {
+		result[key] = value
+	}
+	for _, binding := range appInstance.Spec.NodeSelectors {
+		if binding.Target == "" {
+			result[binding.Key] = binding.Value
+		}
+	}
+	for _, binding := range appInstance.Spec.NodeSelectors {
+		if binding.Target == name {
+			result[binding.Key] = binding.Value
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func toTolerations(container v1.Container) (result []corev1.Toleration) {
+	for _, toleration := range container.Tolerations {
+		result = append(result, corev1.Toleration{
+			Key:      toleration.Key,
+			Operator: corev1.TolerationOperator(toleration.Operator),
+			Value:    toleration.Value,
+			Effect:   corev1.TaintEffect(toleration.Effect),
+		})
+	}
+	return
+}
+
+// toSpread prefers to place the replicas of the container in different zones or on different nodes. The
+// constraints are not required so that pods can still be scheduled when there are more replicas than zones or
+// nodes.
+func toSpread(container v1.Container, matchLabels map[string]string) (*corev1.Affinity, []corev1.TopologySpreadConstraint) {
+	topologyKey, ok := spreadTopologyKeys[container.Spread]
+	if !ok {
+		return nil, nil
+	}
+
+	selector := &metav1.LabelSelector{
+		MatchLabels: matchLabels,
+	}
+
+	affinity := &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: selector,
+						TopologyKey:   topologyKey,
+					},
+				},
+			},
+		},
+	}
+
+	return affinity, []corev1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       topologyKey,
+			WhenUnsatisfiable: corev1.ScheduleAnyway,
+			LabelSelector:     selector,
+		},
+	}
+}
@@ -17,6 +17,7 @@ func PullAppImage(transport http.RoundTripper) router.HandlerFunc {
 	return func(req router.Request, resp router.Response) error {
 		appInstance := req.Object.(*v1.AppInstance)
 		cond := condition.Setter(appInstance, resp, v1.AppInstanceConditionPulled)
+		appInstance.Status.NextMaintenanceWindow = nil
 
 		targetImage, unknownReason := determineTargetImage(appInstance)
 		if targetImage == "" {
@@ -28,6 +29,17 @@ func PullAppImage(transport http.RoundTripper) router.HandlerFunc {
 			return nil
 		}
 
+		if msg, wait, err := waitForMaintenanceWindow(req, resp, appInstance, targetImage); err != nil {
+			cond.Error(err)
+			return nil
+		} else if wait {
+			cond.Set(v1.Condition{
+				Success: true,
+				Message: msg,
+			})
+			return nil
+		}
+
 		resolvedImage, _, err := tags.ResolveLocal(req.Ctx, req.Client, appInstance.Namespace, targetImage)
 		if err != nil {
 			cond.Error(err)
@@ -40,6 +52,7 @@ func PullAppImage(transport http.RoundTripper) router.HandlerFunc {
 			return nil
 		}
 		appImage.Name = targetImage
+		trackAutoUpgrade(appInstance, targetImage, appImage)
 		appInstance.Status.AvailableAppImage = ""
 		appInstance.Status.ConfirmUpgradeAppImage = ""
 		appInstance.Status.AppImage = *appImage
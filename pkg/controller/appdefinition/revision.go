@@ -0,0 +1,119 @@
+package appdefinition
+
+import (
+	"sort"
+	"strconv"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/config"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/acorn-io/baaah/pkg/uncached"
+	"github.com/rancher/wrangler/pkg/name"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RecordRevision stores an AppRevisionInstance each time the spec or the resolved app image of the app changes
+// so that the app can be rolled back. Only the newest revisions, up to the configured limit, are kept; older
+// revisions are no longer emitted and are pruned.
+func RecordRevision(req router.Request, resp router.Response) error {
+	appInstance := req.Object.(*v1.AppInstance)
+
+	cfg, err := config.Get(req.Ctx, req.Client)
+	if err != nil {
+		return err
+	}
+	limit := *cfg.AppRevisionHistoryLimit
+	if limit <= 0 {
+		return nil
+	}
+
+	revisions, err := listRevisions(req, appInstance, false)
+	if err != nil {
+		return err
+	}
+
+	// Don't record a revision while an image is pending, otherwise the new spec would be paired with the old image
+	if targetImage, _ := determineTargetImage(appInstance); targetImage == "" && appInstance.Status.AppImage.Digest != "" {
+		if len(revisions) == 0 || !isCurrentRevision(appInstance, revisions[len(revisions)-1]) {
+			// The cache may not have the latest revision yet, so check again before adding a new one
+			revisions, err = listRevisions(req, appInstance, true)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(revisions) == 0 || !isCurrentRevision(appInstance, revisions[len(revisions)-1]) {
+			next := int64(1)
+			if len(revisions) > 0 {
+				next = revisions[len(revisions)-1].Revision + 1
+			}
+			revisions = append(revisions, *toRevision(appInstance, next, &appInstance.Spec, &appInstance.Status.AppImage))
+		}
+	}
+
+	if len(revisions) > limit {
+		revisions = revisions[len(revisions)-limit:]
+	}
+
+	for _, revision := range revisions {
+		resp.Objects(toRevision(appInstance, revision.Revision, &revision.Spec, &revision.AppImage))
+	}
+
+	return nil
+}
+
+func listRevisions(req router.Request, appInstance *v1.AppInstance, live bool) ([]v1.AppRevisionInstance, error) {
+	var (
+		revisions = &v1.AppRevisionInstanceList{}
+		list      kclient.ObjectList
+	)
+
+	list = revisions
+	if live {
+		list = uncached.List(revisions)
+	}
+
+	err := req.List(list, &kclient.ListOptions{
+		Namespace: appInstance.Namespace,
+		LabelSelector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornAppName: appInstance.Name,
+			labels.AcornAppUID:  string(appInstance.UID),
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(revisions.Items, func(i, j int) bool {
+		return revisions.Items[i].Revision < revisions.Items[j].Revision
+	})
+	return revisions.Items, nil
+}
+
+func isCurrentRevision(appInstance *v1.AppInstance, revision v1.AppRevisionInstance) bool {
+	return revision.AppImage.Digest == appInstance.Status.AppImage.Digest &&
+		equality.Semantic.DeepEqual(revision.Spec, appInstance.Spec)
+}
+
+func toRevision(appInstance *v1.AppInstance, revision int64, spec *v1.AppInstanceSpec, appImage *v1.AppImage) *v1.AppRevisionInstance {
+	return &v1.AppRevisionInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name.SafeConcatName(appInstance.Name, appInstance.ShortID(), strconv.FormatInt(revision, 10)),
+			Namespace: appInstance.Namespace,
+			Labels: map[string]string{
+				labels.AcornAppName: appInstance.Name,
+				labels.AcornAppUID:  string(appInstance.UID),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(appInstance, v1.SchemeGroupVersion.WithKind("AppInstance")),
+			},
+		},
+		Revision: revision,
+		Spec:     *spec.DeepCopy(),
+		AppImage: *appImage.DeepCopy(),
+	}
+}
@@ -0,0 +1,48 @@
+package appdefinition
+
+import (
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// setRollout applies the rollout settings of the container to its deployment. Containers with a persistent volume
+// are recreated, so only minReadySeconds applies to them, the app spec rejects maxSurge and maxUnavailable for them.
+func setRollout(dep *appsv1.Deployment, container v1.Container) {
+	rollout := container.Rollout
+	if rollout == nil {
+		return
+	}
+
+	dep.Spec.MinReadySeconds = rollout.MinReadySeconds
+	if dep.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType ||
+		(rollout.MaxSurge == nil && rollout.MaxUnavailable == nil) {
+		return
+	}
+
+	dep.Spec.Strategy = appsv1.DeploymentStrategy{
+		Type: appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateDeployment{
+			MaxSurge:       rollout.MaxSurge,
+			MaxUnavailable: rollout.MaxUnavailable,
+		},
+	}
+}
+
+func toPodDisruptionBudget(appInstance *v1.AppInstance, dep *appsv1.Deployment, container v1.Container) kclient.Object {
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        dep.Name,
+			Namespace:   appInstance.Status.Namespace,
+			Labels:      containerLabels(appInstance, container, dep.Name),
+			Annotations: containerAnnotations(appInstance, container, dep.Name),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector:       dep.Spec.Selector,
+			MinAvailable:   container.Disruption.MinAvailable,
+			MaxUnavailable: container.Disruption.MaxUnavailable,
+		},
+	}
+}
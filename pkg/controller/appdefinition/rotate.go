@@ -0,0 +1,139 @@
+package appdefinition
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/rancher/wrangler/pkg/data/convert"
+	"github.com/robfig/cron/v3"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// previousKeyPrefix is prepended to a key to keep its value from before the last rotation
+	previousKeyPrefix        = "previous-"
+	defaultRotateGracePeriod = 24 * time.Hour
+)
+
+// rotationSchedule returns when the values of a secret are regenerated, or nil if they are never rotated on a
+// schedule. The rotate param is either a duration or a cron expression.
+func rotationSchedule(secretRef v1.Secret) (cron.Schedule, error) {
+	rotate := convert.ToString(secretRef.Params["rotate"])
+	if rotate == "" {
+		return nil, nil
+	}
+	if d, err := time.ParseDuration(rotate); err == nil {
+		if d <= 0 {
+			return nil, fmt.Errorf("invalid rotate %s: must be greater than zero", rotate)
+		}
+		return cron.Every(d), nil
+	}
+	schedule, err := cron.ParseStandard(rotate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rotate %s: must be a duration or a cron expression: %w", rotate, err)
+	}
+	return schedule, nil
+}
+
+func rotateGracePeriod(secretRef v1.Secret) (time.Duration, error) {
+	gracePeriod := convert.ToString(secretRef.Params["rotateGracePeriod"])
+	if gracePeriod == "" {
+		return defaultRotateGracePeriod, nil
+	}
+	d, err := time.ParseDuration(gracePeriod)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rotateGracePeriod %s: %w", gracePeriod, err)
+	}
+	return d, nil
+}
+
+func lastRotation(secret *corev1.Secret) time.Time {
+	if t, err := time.Parse(time.RFC3339, secret.Annotations[labels.AcornSecretRotatedAt]); err == nil {
+		return t
+	}
+	return secret.CreationTimestamp.Time
+}
+
+// rotate clears the generated values of keys when the secret is due for rotation, either because of its schedule or
+// because a rotation was requested with acorn secret rotate, so they are generated again. The old values are kept
+// under previous-<key> until the grace period ends. Values set in the Acornfile are never rotated.
+func rotate(secretRef v1.Secret, existing, secret *corev1.Secret, now time.Time, keys ...string) error {
+	schedule, err := rotationSchedule(secretRef)
+	if err != nil {
+		return err
+	}
+	gracePeriod, err := rotateGracePeriod(secretRef)
+	if err != nil {
+		return err
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+
+	if existing == nil {
+		if schedule != nil {
+			secret.Annotations[labels.AcornSecretRotatedAt] = now.UTC().Format(time.RFC3339)
+		}
+		return nil
+	}
+
+	for _, key := range []string{labels.AcornSecretRotatedAt, labels.AcornSecretPreviousExpires} {
+		if v, ok := existing.Annotations[key]; ok {
+			secret.Annotations[key] = v
+		}
+	}
+	for _, key := range keys {
+		if v, ok := existing.Data[previousKeyPrefix+key]; ok {
+			secret.Data[previousKeyPrefix+key] = v
+		}
+	}
+
+	if expires, err := time.Parse(time.RFC3339, secret.Annotations[labels.AcornSecretPreviousExpires]); err == nil && !now.Before(expires) {
+		for _, key := range keys {
+			delete(secret.Data, previousKeyPrefix+key)
+		}
+		delete(secret.Annotations, labels.AcornSecretPreviousExpires)
+	}
+
+	_, requested := existing.Annotations[labels.AcornSecretRotate]
+	if !requested && (schedule == nil || now.Before(schedule.Next(lastRotation(existing)))) {
+		return nil
+	}
+
+	for _, key := range keys {
+		if len(secretRef.Data[key]) > 0 || len(secret.Data[key]) == 0 {
+			continue
+		}
+		secret.Data[previousKeyPrefix+key] = secret.Data[key]
+		secret.Data[key] = nil
+	}
+	secret.Annotations[labels.AcornSecretRotatedAt] = now.UTC().Format(time.RFC3339)
+	secret.Annotations[labels.AcornSecretPreviousExpires] = now.Add(gracePeriod).UTC().Format(time.RFC3339)
+	return nil
+}
+
+// nextRotation returns when the generated secret has to be rotated next or its previous values removed
+func nextRotation(secretRef v1.Secret, secret *corev1.Secret) (time.Time, bool) {
+	if secret.Labels[labels.AcornSecretGenerated] != "true" {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	if schedule, err := rotationSchedule(secretRef); err == nil && schedule != nil {
+		next = schedule.Next(lastRotation(secret))
+	}
+	if expires, err := time.Parse(time.RFC3339, secret.Annotations[labels.AcornSecretPreviousExpires]); err == nil &&
+		(next.IsZero() || expires.Before(next)) {
+		next = expires
+	}
+	return next, !next.IsZero()
+}
+
+// isPreviousKey returns true if key holds the value of a rotated secret from before its last rotation
+func isPreviousKey(secretType corev1.SecretType, key string) bool {
+	return (secretType == v1.SecretTypeToken || secretType == v1.SecretTypeBasic) && strings.HasPrefix(key, previousKeyPrefix)
+}
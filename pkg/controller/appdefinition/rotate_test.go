@@ -0,0 +1,203 @@
+package appdefinition
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func rotateTestSecrets(rotatedAt time.Time, annotations map[string]string) (*corev1.Secret, *corev1.Secret) {
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "token-abcde",
+			Namespace: "app-ns",
+			Annotations: map[string]string{
+				labels.AcornSecretRotatedAt: rotatedAt.UTC().Format(time.RFC3339),
+			},
+		},
+		Data: map[string][]byte{
+			"token": []byte("old"),
+		},
+	}
+	for k, v := range annotations {
+		existing.Annotations[k] = v
+	}
+	return existing, &corev1.Secret{
+		Data: seedData(existing, nil, "token"),
+	}
+}
+
+func TestRotateOnSchedule(t *testing.T) {
+	now := time.Now()
+	secretRef := v1.Secret{
+		Type: "token",
+		Params: v1.GenericMap{
+			"rotate": "720h",
+		},
+	}
+
+	// Not due yet
+	existing, secret := rotateTestSecrets(now.Add(-719*time.Hour), nil)
+	require.NoError(t, rotate(secretRef, existing, secret, now, "token"))
+	assert.Equal(t, "old", string(secret.Data["token"]))
+	assert.NotContains(t, secret.Data, "previous-token")
+
+	existing, secret = rotateTestSecrets(now.Add(-721*time.Hour), nil)
+	require.NoError(t, rotate(secretRef, existing, secret, now, "token"))
+	assert.Empty(t, secret.Data["token"])
+	assert.Equal(t, "old", string(secret.Data["previous-token"]))
+	assert.Equal(t, now.UTC().Format(time.RFC3339), secret.Annotations[labels.AcornSecretRotatedAt])
+	assert.Equal(t, now.Add(24*time.Hour).UTC().Format(time.RFC3339), secret.Annotations[labels.AcornSecretPreviousExpires])
+}
+
+func TestRotateCronSchedule(t *testing.T) {
+	secretRef := v1.Secret{
+		Type: "token",
+		Params: v1.GenericMap{
+			// Every day at midnight
+			"rotate":            "0 0 * * *",
+			"rotateGracePeriod": "1h",
+		},
+	}
+
+	now := time.Date(2023, 1, 2, 0, 30, 0, 0, time.Local)
+	existing, secret := rotateTestSecrets(now.Add(-time.Hour), nil)
+	require.NoError(t, rotate(secretRef, existing, secret, now, "token"))
+	assert.Equal(t, "old", string(secret.Data["previous-token"]))
+	assert.Equal(t, now.Add(time.Hour).UTC().Format(time.RFC3339), secret.Annotations[labels.AcornSecretPreviousExpires])
+
+	existing, secret = rotateTestSecrets(now.Add(-time.Minute), nil)
+	require.NoError(t, rotate(secretRef, existing, secret, now, "token"))
+	assert.Equal(t, "old", string(secret.Data["token"]))
+}
+
+func TestRotateRequested(t *testing.T) {
+	now := time.Now()
+	existing, secret := rotateTestSecrets(now, map[string]string{
+		labels.AcornSecretRotate: now.UTC().Format(time.RFC3339),
+	})
+	require.NoError(t, rotate(v1.Secret{Type: "token"}, existing, secret, now, "token"))
+	assert.Empty(t, secret.Data["token"])
+	assert.Equal(t, "old", string(secret.Data["previous-token"]))
+	assert.NotContains(t, secret.Annotations, labels.AcornSecretRotate)
+
+	// Values from the Acornfile are never rotated
+	existing, secret = rotateTestSecrets(now, map[string]string{
+		labels.AcornSecretRotate: now.UTC().Format(time.RFC3339),
+	})
+	require.NoError(t, rotate(v1.Secret{Type: "token", Data: map[string]string{"token": "old"}}, existing, secret, now, "token"))
+	assert.Equal(t, "old", string(secret.Data["token"]))
+	assert.NotContains(t, secret.Data, "previous-token")
+}
+
+func TestRotatePreviousExpires(t *testing.T) {
+	now := time.Now()
+	existing, secret := rotateTestSecrets(now.Add(-2*time.Hour), map[string]string{
+		labels.AcornSecretPreviousExpires: now.Add(-time.Hour).UTC().Format(time.RFC3339),
+	})
+	existing.Data["previous-token"] = []byte("older")
+	require.NoError(t, rotate(v1.Secret{Type: "token"}, existing, secret, now, "token"))
+	assert.Equal(t, "old", string(secret.Data["token"]))
+	assert.NotContains(t, secret.Data, "previous-token")
+	assert.NotContains(t, secret.Annotations, labels.AcornSecretPreviousExpires)
+
+	existing, secret = rotateTestSecrets(now.Add(-2*time.Hour), map[string]string{
+		labels.AcornSecretPreviousExpires: now.Add(time.Hour).UTC().Format(time.RFC3339),
+	})
+	existing.Data["previous-token"] = []byte("older")
+	require.NoError(t, rotate(v1.Secret{Type: "token"}, existing, secret, now, "token"))
+	assert.Equal(t, "older", string(secret.Data["previous-token"]))
+}
+
+func TestRotateToken_Gen(t *testing.T) {
+	now := time.Now()
+	app := &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name",
+			Namespace: "app-ns",
+		},
+		Status: v1.AppInstanceStatus{
+			AppSpec: v1.AppSpec{
+				Secrets: map[string]v1.Secret{
+					"token": {
+						Type: "token",
+						Params: v1.GenericMap{
+							"characters": "abc",
+							"length":     int64(32),
+							"rotate":     "1h",
+						},
+					},
+				},
+			},
+		},
+	}
+	existing, _ := rotateTestSecrets(now.Add(-2*time.Hour), nil)
+
+	req := tester.NewRequest(t, scheme.Scheme, app, existing)
+	secret, err := generateToken(req, app, "token", app.Status.AppSpec.Secrets["token"], existing)
+	require.NoError(t, err)
+	assert.Len(t, secret.Data["token"], 32)
+	assert.Equal(t, "old", string(secret.Data["previous-token"]))
+
+	next, ok := nextRotation(app.Status.AppSpec.Secrets["token"], &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				labels.AcornSecretGenerated: "true",
+			},
+			Annotations: secret.Annotations,
+		},
+	})
+	assert.True(t, ok)
+	assert.WithinDuration(t, now.Add(time.Hour), next, 2*time.Second)
+}
+
+func TestRotate(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)
+	}
+	defer func() {
+		timeNow = time.Now
+	}()
+
+	// The tokens in testdata rotate every 30 days, the due token was last rotated 40 days ago and the others 9 days ago
+	for _, test := range []struct {
+		dir      string
+		delay    time.Duration
+		updates  int
+		previous string
+	}{
+		{dir: "not-due", delay: 21 * 24 * time.Hour},
+		// The previous token expired a day after the last rotation and is removed
+		{dir: "previous-expired", delay: 21 * 24 * time.Hour, updates: 1},
+		// The previous token is kept for the grace period, which ends before the next rotation
+		{dir: "due", delay: 24 * time.Hour, updates: 1, previous: "abcabcab"},
+	} {
+		path := filepath.Join("testdata/secret-rotate", test.dir)
+		t.Run(path, func(t *testing.T) {
+			harness, input, err := tester.FromDir(scheme.Scheme, path)
+			require.NoError(t, err)
+			harness.ExpectedDelay = test.delay
+			resp, err := harness.InvokeFunc(t, input, CreateSecrets)
+			require.NoError(t, err)
+
+			assert.Len(t, resp.Client.Updated, test.updates)
+			for _, obj := range resp.Client.Updated {
+				secret, ok := obj.(*corev1.Secret)
+				if !ok || secret.Namespace != "app-ns" {
+					continue
+				}
+				assert.Len(t, secret.Data["token"], 8)
+				assert.Equal(t, test.previous, string(secret.Data["previous-token"]))
+			}
+		})
+	}
+}
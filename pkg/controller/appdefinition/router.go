@@ -3,6 +3,9 @@ package appdefinition
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
+	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -155,50 +158,169 @@ func toRouter(appInstance *v1.AppInstance, routerName string, router v1.Router)
 }
 
 func toNginxConf(routerName string, router v1.Router) (string, string) {
+	httpBuf := &strings.Builder{}
 	buf := &strings.Builder{}
 	buf.WriteString("server {\nlisten 8080;\n")
-	for _, route := range router.Routes {
-		if route.TargetServiceName == "" || route.Path == "" {
+	for i, route := range router.Routes {
+		if route.Path == "" {
 			continue
 		}
-		port := 80
-		if route.TargetPort != 0 {
-			port = route.TargetPort
+		var proxyPass string
+		if route.Redirect != nil {
+			if route.Redirect.URL == "" {
+				continue
+			}
+		} else if len(route.Targets) > 0 {
+			upstream, ok := writeRouteUpstreams(httpBuf, fmt.Sprintf("route_%d", i), route.Targets)
+			if !ok {
+				continue
+			}
+			proxyPass = "http://" + upstream
+		} else if route.TargetServiceName != "" {
+			proxyPass = "http://" + targetAddress(route.TargetServiceName, route.TargetPort)
+		} else {
+			continue
 		}
-		buf.WriteString("location ")
-		buf.WriteString("= ")
-		buf.WriteString(route.Path)
-		buf.WriteString(" {\n  proxy_pass ")
-		buf.WriteString("http://")
-		buf.WriteString(route.TargetServiceName)
-		buf.WriteString(":")
-		buf.WriteString(strconv.Itoa(port))
-		buf.WriteString(";\n}\n")
+		writeLocation(buf, "= "+route.Path, route, proxyPass)
 		if route.PathType == v1.PathTypePrefix && !strings.HasSuffix(route.Path, "/") {
-			buf.WriteString("location ")
-			buf.WriteString(route.Path)
-			buf.WriteString("/")
-			buf.WriteString(" {\n  proxy_pass ")
-			buf.WriteString("http://")
-			buf.WriteString(route.TargetServiceName)
-			buf.WriteString(":")
-			buf.WriteString(strconv.Itoa(port))
-			buf.WriteString(";\n}\n")
+			writeLocation(buf, route.Path+"/", route, proxyPass)
 		}
 		if route.PathType == v1.PathTypePrefix && route.Path == "/" {
-			buf.WriteString("location ")
-			buf.WriteString("/")
-			buf.WriteString(" {\n  proxy_pass ")
-			buf.WriteString("http://")
-			buf.WriteString(route.TargetServiceName)
-			buf.WriteString(":")
-			buf.WriteString(strconv.Itoa(port))
-			buf.WriteString(";\n}\n")
+			writeLocation(buf, "/", route, proxyPass)
 		}
 	}
 	buf.WriteString("}\n")
 
-	conf := buf.String()
+	conf := httpBuf.String() + buf.String()
 	hash := sha256.Sum256([]byte(conf))
 	return conf, name2.SafeConcatName(routerName, hex.EncodeToString(hash[:])[:8])
 }
+
+// writeLocation writes a location block that either redirects or proxies the requests of a route to proxyPass, after
+// rewriting the path and headers of the request.
+func writeLocation(buf *strings.Builder, location string, route v1.Route, proxyPass string) {
+	buf.WriteString("location ")
+	buf.WriteString(location)
+	buf.WriteString(" {\n")
+	if route.Redirect != nil {
+		statusCode := route.Redirect.StatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusFound
+		}
+		buf.WriteString("  return ")
+		buf.WriteString(strconv.Itoa(statusCode))
+		buf.WriteString(" ")
+		buf.WriteString(nginxQuote(route.Redirect.URL))
+		buf.WriteString(";\n}\n")
+		return
+	}
+	if route.Rewrite != "" {
+		buf.WriteString("  rewrite ")
+		if strings.HasPrefix(location, "= ") {
+			buf.WriteString("^ ")
+			buf.WriteString(nginxQuote(route.Rewrite))
+		} else {
+			// The rest of the path after the matched prefix is appended to the rewritten path
+			rewrite := route.Rewrite
+			if !strings.HasSuffix(rewrite, "/") {
+				rewrite += "/"
+			}
+			buf.WriteString(nginxQuote("^" + regexp.QuoteMeta(location) + "(.*)$"))
+			buf.WriteString(" ")
+			buf.WriteString(nginxQuote(rewrite + "$1"))
+		}
+		buf.WriteString(" break;\n")
+	}
+	for _, header := range typed.Sorted(route.SetRequestHeaders) {
+		buf.WriteString("  proxy_set_header ")
+		buf.WriteString(header.Key)
+		buf.WriteString(" ")
+		buf.WriteString(nginxQuote(header.Value))
+		buf.WriteString(";\n")
+	}
+	for _, header := range route.RemoveResponseHeaders {
+		buf.WriteString("  proxy_hide_header ")
+		buf.WriteString(header)
+		buf.WriteString(";\n")
+	}
+	buf.WriteString("  proxy_pass ")
+	buf.WriteString(proxyPass)
+	buf.WriteString(";\n}\n")
+}
+
+func targetAddress(serviceName string, port int) string {
+	if port == 0 {
+		port = 80
+	}
+	return serviceName + ":" + strconv.Itoa(port)
+}
+
+// writeRouteUpstreams writes the upstreams and maps that pick one of the targets of a route. Targets with a weight are
+// put in one upstream that nginx balances between by weight. Each target with headers gets its own upstream and a map
+// that selects it when a request has all of its headers. The upstream to proxy to is returned, or false if there are
+// no weighted targets, so no request could be routed.
+func writeRouteUpstreams(buf *strings.Builder, name string, targets []v1.RouteTarget) (string, bool) {
+	var weighted []v1.RouteTarget
+	for _, target := range targets {
+		if target.TargetServiceName != "" && target.Weight > 0 {
+			weighted = append(weighted, target)
+		}
+	}
+	if len(weighted) == 0 {
+		return "", false
+	}
+
+	buf.WriteString("upstream ")
+	buf.WriteString(name)
+	buf.WriteString(" {\n")
+	for _, target := range weighted {
+		buf.WriteString("  server ")
+		buf.WriteString(targetAddress(target.TargetServiceName, target.TargetPort))
+		buf.WriteString(" weight=")
+		buf.WriteString(strconv.Itoa(target.Weight))
+		buf.WriteString(";\n")
+	}
+	buf.WriteString("}\n")
+
+	// Maps are chained from the last target to the first, so the first target whose headers match wins and
+	// requests that don't match any fall through to the weighted upstream
+	selected := name
+	for i := len(targets) - 1; i >= 0; i-- {
+		target := targets[i]
+		if target.TargetServiceName == "" || len(target.Headers) == 0 {
+			continue
+		}
+
+		upstream := fmt.Sprintf("%s_%d", name, i)
+		buf.WriteString("upstream ")
+		buf.WriteString(upstream)
+		buf.WriteString(" {\n  server ")
+		buf.WriteString(targetAddress(target.TargetServiceName, target.TargetPort))
+		buf.WriteString(";\n}\n")
+
+		var vars, values []string
+		for _, header := range typed.Sorted(target.Headers) {
+			vars = append(vars, "$http_"+strings.ReplaceAll(strings.ToLower(header.Key), "-", "_"))
+			values = append(values, header.Value)
+		}
+
+		buf.WriteString("map ")
+		buf.WriteString(nginxQuote(strings.Join(vars, "|")))
+		buf.WriteString(" $")
+		buf.WriteString(upstream)
+		buf.WriteString("_selected {\n  ")
+		buf.WriteString(nginxQuote(strings.Join(values, "|")))
+		buf.WriteString(" ")
+		buf.WriteString(upstream)
+		buf.WriteString(";\n  default ")
+		buf.WriteString(selected)
+		buf.WriteString(";\n}\n")
+		selected = "$" + upstream + "_selected"
+	}
+
+	return selected, true
+}
+
+func nginxQuote(s string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+}
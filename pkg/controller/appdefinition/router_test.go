@@ -3,10 +3,182 @@ package appdefinition
 import (
 	"testing"
 
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/scheme"
 	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRouter(t *testing.T) {
 	tester.DefaultTest(t, scheme.Scheme, "testdata/router", DeploySpec)
 }
+
+func TestWeightedRouterConf(t *testing.T) {
+	conf, _ := toNginxConf("router-name", v1.Router{
+		Routes: []v1.Route{
+			{
+				Path:     "/",
+				PathType: v1.PathTypePrefix,
+				Targets: []v1.RouteTarget{
+					{
+						TargetServiceName: "web",
+						Weight:            90,
+					},
+					{
+						TargetServiceName: "web-canary",
+						TargetPort:        8080,
+						Weight:            10,
+						Headers: map[string]string{
+							"X-Canary": "true",
+						},
+					},
+					{
+						TargetServiceName: "web-next",
+						Headers: map[string]string{
+							"X-Canary": "next",
+							"X-Team":   "dev",
+						},
+					},
+				},
+			},
+			{
+				Path:     "/api",
+				PathType: v1.PathTypeExact,
+				Targets: []v1.RouteTarget{
+					{
+						TargetServiceName: "api",
+						Weight:            1,
+					},
+					{
+						TargetServiceName: "api-next",
+						Weight:            1,
+					},
+				},
+			},
+			{
+				// Only reachable by header, so there is nothing to send other requests to
+				Path: "/preview",
+				Targets: []v1.RouteTarget{
+					{
+						TargetServiceName: "preview",
+						Headers: map[string]string{
+							"X-Preview": "true",
+						},
+					},
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, `upstream route_0 {
+  server web:80 weight=90;
+  server web-canary:8080 weight=10;
+}
+upstream route_0_2 {
+  server web-next:80;
+}
+map "$http_x_canary|$http_x_team" $route_0_2_selected {
+  "next|dev" route_0_2;
+  default route_0;
+}
+upstream route_0_1 {
+  server web-canary:8080;
+}
+map "$http_x_canary" $route_0_1_selected {
+  "true" route_0_1;
+  default $route_0_2_selected;
+}
+upstream route_1 {
+  server api:80 weight=1;
+  server api-next:80 weight=1;
+}
+server {
+listen 8080;
+location = / {
+  proxy_pass http://$route_0_1_selected;
+}
+location / {
+  proxy_pass http://$route_0_1_selected;
+}
+location = /api {
+  proxy_pass http://route_1;
+}
+}
+`, conf)
+}
+
+func TestRewriteRouterConf(t *testing.T) {
+	conf, _ := toNginxConf("router-name", v1.Router{
+		Routes: []v1.Route{
+			{
+				Path:              "/api",
+				PathType:          v1.PathTypePrefix,
+				TargetServiceName: "api",
+				Rewrite:           "/",
+				SetRequestHeaders: map[string]string{
+					"X-Forwarded-Prefix": "/api",
+					"Accept-Encoding":    "",
+				},
+				RemoveResponseHeaders: []string{"X-Powered-By"},
+			},
+			{
+				Path:              "/v1.0",
+				PathType:          v1.PathTypePrefix,
+				TargetServiceName: "legacy",
+				TargetPort:        8080,
+				Rewrite:           "/v2",
+			},
+			{
+				Path:     "/old",
+				PathType: v1.PathTypePrefix,
+				Redirect: &v1.RouteRedirect{
+					URL:        "https://example.com/new",
+					StatusCode: 301,
+				},
+			},
+			{
+				Path:     "/docs",
+				PathType: v1.PathTypeExact,
+				Redirect: &v1.RouteRedirect{
+					URL: "https://docs.example.com$request_uri",
+				},
+			},
+		},
+	})
+
+	assert.Equal(t, `server {
+listen 8080;
+location = /api {
+  rewrite ^ "/" break;
+  proxy_set_header Accept-Encoding "";
+  proxy_set_header X-Forwarded-Prefix "/api";
+  proxy_hide_header X-Powered-By;
+  proxy_pass http://api:80;
+}
+location /api/ {
+  rewrite "^/api/(.*)$" "/$1" break;
+  proxy_set_header Accept-Encoding "";
+  proxy_set_header X-Forwarded-Prefix "/api";
+  proxy_hide_header X-Powered-By;
+  proxy_pass http://api:80;
+}
+location = /v1.0 {
+  rewrite ^ "/v2" break;
+  proxy_pass http://legacy:8080;
+}
+location /v1.0/ {
+  rewrite "^/v1\\.0/(.*)$" "/v2/$1" break;
+  proxy_pass http://legacy:8080;
+}
+location = /old {
+  return 301 "https://example.com/new";
+}
+location /old/ {
+  return 301 "https://example.com/new";
+}
+location = /docs {
+  return 302 "https://docs.example.com$request_uri";
+}
+}
+`, conf)
+}
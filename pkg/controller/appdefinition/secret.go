@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/condition"
@@ -55,6 +56,9 @@ var (
 	ErrJobNoOutput       = errors.New("job has no output")
 	templateSecretRegexp = regexp.MustCompile(`\${secret://(.*?)/(.*?)}`)
 	imageSecretRegexp    = regexp.MustCompile(`\${image://(.*?)}`)
+
+	// timeNow is replaced in tests to evaluate schedules and expirations at a fixed time
+	timeNow = time.Now
 )
 
 func getCronJobLatestJob(req router.Request, namespace, name string) (jobName string, err error) {
@@ -207,7 +211,21 @@ func generateTemplate(secrets map[string]*corev1.Secret, req router.Request, app
 		return nil, err
 	}
 
+	engine := convert.ToString(secretRef.Params["engine"])
+	if engine != "" && engine != "substitution" && engine != "go" {
+		return nil, fmt.Errorf("unsupported template engine %s", engine)
+	}
+
 	for _, entry := range typed.Sorted(secret.Data) {
+		if engine == "go" {
+			rendered, err := renderGoTemplate(secrets, req, appInstance, tag, entry.Key, string(entry.Value))
+			if err != nil {
+				return nil, err
+			}
+			secret.Data[entry.Key] = []byte(rendered)
+			continue
+		}
+
 		var (
 			template       = string(entry.Value)
 			templateErrors []error
@@ -261,6 +279,10 @@ func generateToken(req router.Request, appInstance *v1.AppInstance, secretName s
 		Type: v1.SecretTypeToken,
 	}
 
+	if err := rotate(secretRef, existing, secret, timeNow(), "token"); err != nil {
+		return nil, err
+	}
+
 	if len(secret.Data["token"]) == 0 {
 		length, err := convert.ToNumber(secretRef.Params["length"])
 		if err != nil {
@@ -304,6 +326,10 @@ func generateBasic(req router.Request, appInstance *v1.AppInstance, secretName s
 		Type: v1.SecretTypeBasic,
 	}
 
+	if err := rotate(secretRef, existing, secret, timeNow(), corev1.BasicAuthPasswordKey); err != nil {
+		return nil, err
+	}
+
 	for i, key := range []string{corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey} {
 		if len(secret.Data[key]) == 0 {
 			// TODO: Improve with more characters (special, upper/lowercase, etc)
@@ -411,6 +437,12 @@ func generateSecret(secrets map[string]*corev1.Secret, req router.Request, appIn
 		return generateToken(req, appInstance, secretName, secretRef, existing)
 	case "template":
 		return generateTemplate(secrets, req, appInstance, secretName, secretRef, existing)
+	case "tls":
+		return generateTLS(req, appInstance, secretName, secretRef, existing)
+	case "ssh-key", "keypair":
+		return generateKeySecret(req, appInstance, secretName, secretRef, existing)
+	case "external":
+		return generateExternal(req, appInstance, secretName, secretRef, existing, timeNow())
 	default:
 		return nil, err
 	}
@@ -588,32 +620,51 @@ func CreateSecrets(req router.Request, resp router.Response) (err error) {
 			continue
 		}
 
-		labelMap := map[string]string{
-			labels.AcornAppName:      appInstance.Name,
-			labels.AcornAppNamespace: appInstance.Namespace,
-			labels.AcornManaged:      "true",
+		if entry.secret.Type == "tls" {
+			if renewAt, ok := certificateRenewalTime(secret); ok {
+				resp.RetryAfter(renewAt.Sub(timeNow()))
+			}
+		} else if entry.secret.Type == "token" || entry.secret.Type == "basic" {
+			if next, ok := nextRotation(entry.secret, secret); ok {
+				resp.RetryAfter(next.Sub(timeNow()))
+			}
+		} else if entry.secret.Type == "external" {
+			if next, ok := nextRefresh(entry.secret, secret); ok {
+				resp.RetryAfter(next.Sub(timeNow()))
+			}
 		}
-		labelMap = labels.Merge(labelMap, labels.GatherScoped(secretName, v1.LabelTypeSecret,
-			appInstance.Status.AppSpec.Labels, entry.secret.Labels, appInstance.Spec.Labels))
-
-		annotations := labels.GatherScoped(secretName, v1.LabelTypeSecret, appInstance.Status.AppSpec.Annotations,
-			entry.secret.Annotations, appInstance.Spec.Annotations)
-
-		resp.Objects(&corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:        secretName,
-				Namespace:   appInstance.Status.Namespace,
-				Labels:      labelMap,
-				Annotations: annotations,
-			},
-			Data: secret.Data,
-			Type: secret.Type,
-		})
+
+		resp.Objects(toAppSecret(appInstance, secretName, entry.secret, secret.Data, secret.Type))
 	}
 
 	return nil
 }
 
+// toAppSecret returns the copy of a secret in the namespace of the app that the containers of the app use
+func toAppSecret(appInstance *v1.AppInstance, secretName string, secretRef v1.Secret, data map[string][]byte, secretType corev1.SecretType) *corev1.Secret {
+	labelMap := map[string]string{
+		labels.AcornAppName:      appInstance.Name,
+		labels.AcornAppNamespace: appInstance.Namespace,
+		labels.AcornManaged:      "true",
+	}
+	labelMap = labels.Merge(labelMap, labels.GatherScoped(secretName, v1.LabelTypeSecret,
+		appInstance.Status.AppSpec.Labels, secretRef.Labels, appInstance.Spec.Labels))
+
+	annotations := labels.GatherScoped(secretName, v1.LabelTypeSecret, appInstance.Status.AppSpec.Annotations,
+		secretRef.Annotations, appInstance.Spec.Annotations)
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretName,
+			Namespace:   appInstance.Status.Namespace,
+			Labels:      labelMap,
+			Annotations: annotations,
+		},
+		Data: data,
+		Type: secretType,
+	}
+}
+
 func generate(characters string, tokenLength int) (string, error) {
 	token := make([]byte, tokenLength)
 	for i := range token {
@@ -277,6 +277,10 @@ func TestSecretImageReference(t *testing.T) {
 	tester.DefaultTest(t, scheme.Scheme, "testdata/secret-image", CreateSecrets)
 }
 
+func TestSecretGoTemplate(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/secret-template-go", CreateSecrets)
+}
+
 func TestSecretLabelsAnnotations(t *testing.T) {
 	h := tester.Harness{
 		Scheme: scheme.Scheme,
@@ -0,0 +1,180 @@
+package appdefinition
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/condition"
+	"github.com/acorn-io/acorn/pkg/config"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/acorn-io/baaah/pkg/typed"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	podSecurityBaseline   = "baseline"
+	podSecurityRestricted = "restricted"
+)
+
+// baselineCapabilities are the capabilities the baseline PodSecurity profile allows to be added to a container
+var baselineCapabilities = sets.NewString(
+	"AUDIT_WRITE",
+	"CHOWN",
+	"DAC_OVERRIDE",
+	"FOWNER",
+	"FSETID",
+	"KILL",
+	"MKNOD",
+	"NET_BIND_SERVICE",
+	"SETFCAP",
+	"SETGID",
+	"SETPCAP",
+	"SETUID",
+	"SYS_CHROOT",
+)
+
+func toSecurityContext(securityContext *v1.SecurityContext) *corev1.SecurityContext {
+	if securityContext == nil {
+		return nil
+	}
+
+	result := &corev1.SecurityContext{
+		RunAsUser:                securityContext.RunAsUser,
+		RunAsGroup:               securityContext.RunAsGroup,
+		RunAsNonRoot:             securityContext.RunAsNonRoot,
+		ReadOnlyRootFilesystem:   securityContext.ReadOnlyRootFilesystem,
+		Privileged:               securityContext.Privileged,
+		AllowPrivilegeEscalation: securityContext.AllowPrivilegeEscalation,
+	}
+
+	if securityContext.Capabilities != nil {
+		result.Capabilities = &corev1.Capabilities{}
+		for _, capability := range securityContext.Capabilities.Add {
+			result.Capabilities.Add = append(result.Capabilities.Add, corev1.Capability(capability))
+		}
+		for _, capability := range securityContext.Capabilities.Drop {
+			result.Capabilities.Drop = append(result.Capabilities.Drop, corev1.Capability(capability))
+		}
+	}
+
+	if securityContext.SeccompProfile != "" {
+		result.SeccompProfile = &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileType(securityContext.SeccompProfile),
+		}
+	}
+
+	return result
+}
+
+// PodSecurityStatus checks the security context of the containers, sidecars and jobs of the app against the
+// PodSecurity profile that is enforced on app namespaces, so violations are reported on the app instead of only
+// failing pod admission.
+func PodSecurityStatus(req router.Request, resp router.Response) error {
+	appInstance := req.Object.(*v1.AppInstance)
+	cond := condition.Setter(appInstance, resp, v1.AppInstanceConditionPodSecurity)
+
+	cfg, err := config.Get(req.Ctx, req.Client)
+	if err != nil {
+		return err
+	}
+
+	if !*cfg.SetPodSecurityEnforceProfile {
+		cond.Success()
+		return nil
+	}
+
+	var violations []string
+	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Containers) {
+		violations = append(violations, containerViolations(cfg.PodSecurityEnforceProfile, "container", entry.Key, entry.Value)...)
+	}
+	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Jobs) {
+		violations = append(violations, containerViolations(cfg.PodSecurityEnforceProfile, "job", entry.Key, entry.Value)...)
+	}
+
+	if len(violations) > 0 {
+		cond.Error(fmt.Errorf("violates PodSecurity %q: %s", cfg.PodSecurityEnforceProfile, strings.Join(violations, ", ")))
+		return nil
+	}
+
+	cond.Success()
+	return nil
+}
+
+func containerViolations(profile, kind, name string, container v1.Container) []string {
+	result := securityContextViolations(profile, kind+" "+name, container.SecurityContext)
+	result = append(result, seedViolations(profile, kind+" "+name, container)...)
+	for _, entry := range typed.Sorted(container.Sidecars) {
+		result = append(result, securityContextViolations(profile, "sidecar "+entry.Key, entry.Value.SecurityContext)...)
+		result = append(result, seedViolations(profile, "sidecar "+entry.Key, entry.Value)...)
+	}
+	return result
+}
+
+// seedViolations checks the init containers that seed the dirs of a container, they get the security context of
+// the container they seed
+func seedViolations(profile, name string, container v1.Container) (result []string) {
+	for _, entry := range typed.Sorted(container.Dirs) {
+		if hasSeed(entry.Value) {
+			result = append(result, securityContextViolations(profile, fmt.Sprintf("seed of %s for %s", name, entry.Key),
+				container.SecurityContext)...)
+		}
+	}
+	return result
+}
+
+// securityContextViolations follows the container level checks of the PodSecurity standards. Containers without a
+// security context are checked as if all settings are unset.
+func securityContextViolations(profile, name string, securityContext *v1.SecurityContext) (result []string) {
+	if profile != podSecurityBaseline && profile != podSecurityRestricted {
+		return nil
+	}
+
+	if securityContext == nil {
+		securityContext = &v1.SecurityContext{}
+	}
+
+	var add, drop []string
+	if securityContext.Capabilities != nil {
+		add, drop = securityContext.Capabilities.Add, securityContext.Capabilities.Drop
+	}
+
+	if securityContext.Privileged != nil && *securityContext.Privileged {
+		result = append(result, fmt.Sprintf("%s must not set privileged=true", name))
+	}
+	if securityContext.SeccompProfile == string(corev1.SeccompProfileTypeUnconfined) {
+		result = append(result, fmt.Sprintf("%s must not set seccompProfile=Unconfined", name))
+	}
+
+	allowed := baselineCapabilities
+	if profile == podSecurityRestricted {
+		allowed = sets.NewString("NET_BIND_SERVICE")
+	}
+	if forbidden := sets.NewString(add...).Difference(allowed); forbidden.Len() > 0 {
+		result = append(result, fmt.Sprintf("%s must not add capabilities %s", name, strings.Join(forbidden.List(), ", ")))
+	}
+
+	if profile != podSecurityRestricted {
+		return result
+	}
+
+	if securityContext.AllowPrivilegeEscalation == nil || *securityContext.AllowPrivilegeEscalation {
+		result = append(result, fmt.Sprintf("%s must set allowPrivilegeEscalation=false", name))
+	}
+	if securityContext.RunAsNonRoot == nil || !*securityContext.RunAsNonRoot {
+		result = append(result, fmt.Sprintf("%s must set runAsNonRoot=true", name))
+	}
+	if securityContext.RunAsUser != nil && *securityContext.RunAsUser == 0 {
+		result = append(result, fmt.Sprintf("%s must not set runAsUser=0", name))
+	}
+	if !sets.NewString(drop...).Has("ALL") {
+		result = append(result, fmt.Sprintf("%s must drop capabilities ALL", name))
+	}
+	// Unconfined is already reported above
+	if securityContext.SeccompProfile == "" {
+		result = append(result, fmt.Sprintf("%s must set seccompProfile=RuntimeDefault", name))
+	}
+
+	return result
+}
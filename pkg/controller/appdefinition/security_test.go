@@ -0,0 +1,83 @@
+package appdefinition
+
+import (
+	"testing"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSecurityContextViolations(t *testing.T) {
+	restricted := &v1.SecurityContext{
+		RunAsUser:                &[]int64{1000}[0],
+		RunAsNonRoot:             &[]bool{true}[0],
+		AllowPrivilegeEscalation: &[]bool{false}[0],
+		Capabilities: &v1.Capabilities{
+			Add:  []string{"NET_BIND_SERVICE"},
+			Drop: []string{"ALL"},
+		},
+		SeccompProfile: "RuntimeDefault",
+	}
+
+	assert.Empty(t, securityContextViolations("privileged", "container web", &v1.SecurityContext{Privileged: &[]bool{true}[0]}))
+	assert.Empty(t, securityContextViolations("baseline", "container web", nil))
+	assert.Empty(t, securityContextViolations("restricted", "container web", restricted))
+
+	assert.Equal(t, []string{
+		"container web must not set privileged=true",
+		"container web must not set seccompProfile=Unconfined",
+		"container web must not add capabilities NET_ADMIN, SYS_ADMIN",
+	}, securityContextViolations("baseline", "container web", &v1.SecurityContext{
+		Privileged:     &[]bool{true}[0],
+		SeccompProfile: "Unconfined",
+		Capabilities: &v1.Capabilities{
+			Add: []string{"CHOWN", "SYS_ADMIN", "NET_ADMIN"},
+		},
+	}))
+
+	assert.Equal(t, []string{
+		"container web must set allowPrivilegeEscalation=false",
+		"container web must set runAsNonRoot=true",
+		"container web must drop capabilities ALL",
+		"container web must set seccompProfile=RuntimeDefault",
+	}, securityContextViolations("restricted", "container web", nil))
+
+	assert.Equal(t, []string{
+		"container web must not add capabilities CHOWN",
+		"container web must not set runAsUser=0",
+	}, securityContextViolations("restricted", "container web", &v1.SecurityContext{
+		RunAsUser:                &[]int64{0}[0],
+		RunAsNonRoot:             &[]bool{true}[0],
+		AllowPrivilegeEscalation: &[]bool{false}[0],
+		Capabilities: &v1.Capabilities{
+			Add:  []string{"CHOWN"},
+			Drop: []string{"ALL"},
+		},
+		SeccompProfile: "RuntimeDefault",
+	}))
+}
+
+func TestContainerViolations(t *testing.T) {
+	violations := containerViolations("baseline", "job", "migrate", v1.Container{
+		Sidecars: map[string]v1.Container{
+			"proxy": {
+				SecurityContext: &v1.SecurityContext{Privileged: &[]bool{true}[0]},
+			},
+		},
+	})
+	assert.Equal(t, []string{"sidecar proxy must not set privileged=true"}, violations)
+}
+
+func TestContainerViolationsSeed(t *testing.T) {
+	violations := containerViolations("baseline", "container", "db", v1.Container{
+		SecurityContext: &v1.SecurityContext{Privileged: &[]bool{true}[0]},
+		Dirs: map[string]v1.VolumeMount{
+			"/var/lib/data": {Volume: "data", SeedFrom: "./seed"},
+			"/tmp":          {Volume: "scratch"},
+		},
+	})
+	assert.Equal(t, []string{
+		"container db must not set privileged=true",
+		"seed of container db for /var/lib/data must not set privileged=true",
+	}, violations)
+}
@@ -0,0 +1,214 @@
+package appdefinition
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/images"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/acorn-io/baaah/pkg/typed"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	SeedContainerPrefix = "acorn-seed-"
+	SeedPath            = "/.acorn-seed"
+)
+
+// toSeedContainers returns the init containers that copy the seed content of dirs into their volume. The content is
+// read from the mount path of the image, where the build copies a seedFrom context dir. Persistent volumes are only
+// seeded until their claim is marked as seeded. The replicas of a stateful container share a pod template, so their
+// volumes are seeded each time a replica starts, without overwriting files that already exist.
+func toSeedContainers(req router.Request, app *v1.AppInstance, tag name.Reference, containerName string, container v1.Container) ([]corev1.Container, error) {
+	result, err := toSeedContainersFor(req, app, tag, containerName, container, container.Stateful)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range typed.Sorted(container.Sidecars) {
+		sidecarContainers, err := toSeedContainersFor(req, app, tag, entry.Key, entry.Value, container.Stateful)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, sidecarContainers...)
+	}
+	return result, nil
+}
+
+func hasSeed(mount v1.VolumeMount) bool {
+	return (mount.SeedFrom != "" || mount.SeedFromImage != "") && mount.Volume != ""
+}
+
+func toSeedContainersFor(req router.Request, app *v1.AppInstance, tag name.Reference, containerName string, container v1.Container, stateful bool) (result []corev1.Container, _ error) {
+	for _, entry := range typed.Sorted(container.Dirs) {
+		mountPath, mount := entry.Key, entry.Value
+		if !hasSeed(mount) {
+			continue
+		}
+
+		// Bound volumes already hold the data they were created with
+		if _, bind := isBind(app, mount.Volume); bind {
+			continue
+		}
+
+		if _, ephemeral := isEphemeral(app, mount.Volume); !ephemeral && !isVolumeTemplated(app, stateful, mount.Volume) {
+			seeded, err := isSeeded(req, app, mount.Volume)
+			if err != nil {
+				return nil, err
+			}
+			if seeded {
+				continue
+			}
+		}
+
+		image := container.Image
+		if mount.SeedFromImage != "" {
+			image = app.Status.AppSpec.Images[strings.TrimPrefix(mount.SeedFromImage, "images.")].Image
+		}
+
+		result = append(result, corev1.Container{
+			Name:  SeedContainerPrefix + pathHash(containerName, mountPath),
+			Image: images.ResolveTag(tag, image),
+			// Only cp is needed in the image, existing files are never overwritten
+			Command: []string{"cp", "-a", "-n", path.Join("/", mountPath) + "/.", SeedPath + "/"},
+			// The seed runs in the same pod as the container, so it is held to the same security context
+			SecurityContext: toSecurityContext(container.SecurityContext),
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      sanitizeVolumeName(mount.Volume),
+					MountPath: SeedPath,
+					SubPath:   mount.SubPath,
+				},
+			},
+		})
+	}
+	return
+}
+
+// isSeeded returns whether the claim of the volume, or the existing volume the claim will be bound to, is marked as
+// seeded
+func isSeeded(req router.Request, app *v1.AppInstance, volume string) (bool, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := req.Get(pvc, app.Status.Namespace, volume); err == nil && pvc.Annotations[labels.AcornVolumeSeeded] == "true" {
+		return true, nil
+	} else if err != nil && !apierror.IsNotFound(err) {
+		return false, err
+	}
+
+	pvName, err := lookupExistingPV(req, app, volume)
+	if err != nil || pvName == "" {
+		return false, err
+	}
+
+	pv := &corev1.PersistentVolume{}
+	if err := req.Get(pv, "", pvName); apierror.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return pv.Annotations[labels.AcornVolumeSeeded] == "true", nil
+}
+
+// MarkVolumesSeeded marks the claims, and their volumes, that the seed init containers of a pod copied content into
+// so the volumes are not seeded again
+func MarkVolumesSeeded(req router.Request, resp router.Response) error {
+	pod := req.Object.(*corev1.Pod)
+
+	for _, status := range pod.Status.InitContainerStatuses {
+		if !strings.HasPrefix(status.Name, SeedContainerPrefix) ||
+			status.State.Terminated == nil || status.State.Terminated.ExitCode != 0 {
+			continue
+		}
+
+		claimName := seedClaimName(pod, status.Name)
+		if claimName == "" {
+			continue
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{}
+		if err := req.Get(pvc, pod.Namespace, claimName); apierror.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if pvc.Spec.VolumeName != "" {
+			pv := &corev1.PersistentVolume{}
+			if err := req.Get(pv, "", pvc.Spec.VolumeName); err == nil && pv.Annotations[labels.AcornVolumeSeeded] != "true" {
+				if pv.Annotations == nil {
+					pv.Annotations = map[string]string{}
+				}
+				pv.Annotations[labels.AcornVolumeSeeded] = "true"
+				if err := req.Client.Update(req.Ctx, pv); err != nil {
+					return err
+				}
+			} else if err != nil && !apierror.IsNotFound(err) {
+				return err
+			}
+		}
+
+		if pvc.Annotations[labels.AcornVolumeSeeded] != "true" {
+			if pvc.Annotations == nil {
+				pvc.Annotations = map[string]string{}
+			}
+			pvc.Annotations[labels.AcornVolumeSeeded] = "true"
+			if err := req.Client.Update(req.Ctx, pvc); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func seedClaimName(pod *corev1.Pod, containerName string) string {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name != containerName || len(container.VolumeMounts) == 0 {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.Name == container.VolumeMounts[0].Name && volume.PersistentVolumeClaim != nil {
+				return volume.PersistentVolumeClaim.ClaimName
+			}
+		}
+	}
+	return ""
+}
+
+// seedMessage explains why a seed init container of the pod could not run, the image it runs in must have cp
+func seedMessage(pod *corev1.Pod, status corev1.ContainerStatus) (string, bool) {
+	if !strings.HasPrefix(status.Name, SeedContainerPrefix) {
+		return "", false
+	}
+
+	var messages []string
+	if status.State.Waiting != nil {
+		messages = append(messages, status.State.Waiting.Message)
+	}
+	for _, terminated := range []*corev1.ContainerStateTerminated{status.State.Terminated, status.LastTerminationState.Terminated} {
+		if terminated != nil {
+			messages = append(messages, terminated.Message)
+		}
+	}
+
+	for _, msg := range messages {
+		if strings.Contains(msg, "executable file not found") || strings.Contains(msg, "no such file or directory") {
+			return fmt.Sprintf("can not seed volume %s, the image has no cp command to copy the seed content",
+				seedVolumeName(pod, status.Name)), true
+		}
+	}
+	return "", false
+}
+
+func seedVolumeName(pod *corev1.Pod, containerName string) string {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == containerName && len(container.VolumeMounts) > 0 {
+			return container.VolumeMounts[0].Name
+		}
+	}
+	return ""
+}
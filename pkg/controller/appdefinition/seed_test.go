@@ -0,0 +1,101 @@
+package appdefinition
+
+import (
+	"testing"
+
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func seedPod(exitCode int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "container-name-abcde",
+			Namespace: "app-created-namespace",
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name: SeedContainerPrefix + "f6565fad2fac",
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "data", MountPath: SeedPath},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{
+				{
+					Name: SeedContainerPrefix + "f6565fad2fac",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestMarkVolumesSeeded(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "app-created-namespace"},
+		Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pvc-data"},
+	}
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-data"},
+	}
+
+	req := tester.NewRequest(t, scheme.Scheme, seedPod(0), pvc, pv)
+	require.NoError(t, MarkVolumesSeeded(req, &tester.Response{Client: req.Client.(*tester.Client)}))
+
+	updated := req.Client.(*tester.Client).Updated
+	if assert.Len(t, updated, 2) {
+		assert.Equal(t, "pvc-data", updated[0].GetName())
+		assert.Equal(t, "true", updated[0].GetAnnotations()[labels.AcornVolumeSeeded])
+		assert.Equal(t, "data", updated[1].GetName())
+		assert.Equal(t, "true", updated[1].GetAnnotations()[labels.AcornVolumeSeeded])
+	}
+}
+
+func TestMarkVolumesSeededFailed(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "app-created-namespace"},
+	}
+
+	req := tester.NewRequest(t, scheme.Scheme, seedPod(1), pvc)
+	require.NoError(t, MarkVolumesSeeded(req, &tester.Response{Client: req.Client.(*tester.Client)}))
+	assert.Empty(t, req.Client.(*tester.Client).Updated)
+}
+
+func TestSeedMessage(t *testing.T) {
+	pod := seedPod(128)
+	status := pod.Status.InitContainerStatuses[0]
+	status.State = corev1.ContainerState{
+		Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 10s restarting failed container"},
+	}
+	status.LastTerminationState.Terminated = &corev1.ContainerStateTerminated{
+		Reason:   "StartError",
+		Message:  `failed to create containerd task: exec: "cp": executable file not found in $PATH: unknown`,
+		ExitCode: 128,
+	}
+
+	msg, ok := seedMessage(pod, status)
+	assert.True(t, ok)
+	assert.Equal(t, "can not seed volume data, the image has no cp command to copy the seed content", msg)
+
+	_, ok = seedMessage(pod, seedPod(1).Status.InitContainerStatuses[0])
+	assert.False(t, ok)
+}
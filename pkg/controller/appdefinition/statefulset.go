@@ -0,0 +1,325 @@
+package appdefinition
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/ports"
+	"github.com/acorn-io/baaah/pkg/apply"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/acorn-io/baaah/pkg/typed"
+	name2 "github.com/rancher/wrangler/pkg/name"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func headlessServiceName(name string) string {
+	return name2.SafeConcatName(name, "headless")
+}
+
+// isVolumeTemplated returns true if each replica of a stateful container gets its own claim for the volume. Bound
+// volumes already exist, so they are shared by all replicas.
+func isVolumeTemplated(appInstance *v1.AppInstance, stateful bool, volume string) bool {
+	if !stateful || volume == "" || volume == AcornHelper {
+		return false
+	}
+	if _, bind := isBind(appInstance, volume); bind {
+		return false
+	}
+	_, ephemeral := isEphemeral(appInstance, volume)
+	return !ephemeral
+}
+
+func templatedVolumes(appInstance *v1.AppInstance, container v1.Container) []string {
+	volumeReferences := map[volumeReference]bool{}
+	addVolumeReferencesForContainer(appInstance, volumeReferences, container)
+	for _, entry := range typed.Sorted(container.Sidecars) {
+		addVolumeReferencesForContainer(appInstance, volumeReferences, entry.Value)
+	}
+
+	names := sets.NewString()
+	for volume := range volumeReferences {
+		if isVolumeTemplated(appInstance, container.Stateful, volume.name) {
+			names.Insert(volume.name)
+		}
+	}
+	return names.List()
+}
+
+// statefulVolumes returns the volumes that are created from the claim templates of StatefulSets instead of as a
+// single claim, and the container each belongs to
+func statefulVolumes(appInstance *v1.AppInstance) map[string]string {
+	result := map[string]string{}
+	for name, container := range appInstance.Status.AppSpec.Containers {
+		if ports.IsLinked(appInstance, name) {
+			continue
+		}
+		for _, volume := range templatedVolumes(appInstance, container) {
+			result[volume] = name
+		}
+	}
+	return result
+}
+
+// isReplicaClaim returns true if the claim was created by the StatefulSet of the container from the claim template
+// of the volume. These claims are named <volume>-<container>-<replica>.
+func isReplicaClaim(claimName, volume, containerName string) bool {
+	prefix := volume + "-" + containerName + "-"
+	if !strings.HasPrefix(claimName, prefix) {
+		return false
+	}
+	_, err := strconv.Atoi(strings.TrimPrefix(claimName, prefix))
+	return err == nil
+}
+
+func replicaClaimName(volume, containerName string, replica int) string {
+	return fmt.Sprintf("%s-%s-%d", volume, containerName, replica)
+}
+
+// migratedPV returns the existing volume of a volume that had a single claim before the container became stateful,
+// as long as the claim of the first replica doesn't exist. The first replica is bound to it to keep its data.
+func migratedPV(req router.Request, appInstance *v1.AppInstance, containerName, volume string) (*corev1.PersistentVolume, error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := req.Get(pvc, appInstance.Status.Namespace, replicaClaimName(volume, containerName, 0)); err == nil {
+		return nil, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	pvName, err := lookupExistingPV(req, appInstance, volume)
+	if err != nil || pvName == "" {
+		return nil, err
+	}
+
+	pv := &corev1.PersistentVolume{}
+	if err := req.Get(pv, "", pvName); apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return pv, nil
+}
+
+// isMigrating returns true while the claim of the first replica is not yet created for a volume that is migrated
+// from the single claim the volume had before the container became stateful
+func isMigrating(req router.Request, appInstance *v1.AppInstance, containerName string, container v1.Container) (bool, error) {
+	for _, volume := range templatedVolumes(appInstance, container) {
+		pv, err := migratedPV(req, appInstance, containerName, volume)
+		if err != nil || pv != nil {
+			return pv != nil, err
+		}
+	}
+	return false, nil
+}
+
+// toReplicaPVCs returns the claims the StatefulSet of the container created for each replica from the claim template
+// of the volume, with the size of the volume. Claim templates can't be changed, so the claims are expanded directly
+// like the claims of other volumes. Claims are only updated, never created, as the StatefulSet creates them.
+func toReplicaPVCs(req router.Request, appInstance *v1.AppInstance, containerName, volume string) (result []kclient.Object, _ error) {
+	var pvcs corev1.PersistentVolumeClaimList
+	err := req.List(&pvcs, &kclient.ListOptions{
+		Namespace:     appInstance.Status.Namespace,
+		LabelSelector: klabels.SelectorFromSet(selectorMatchLabels(appInstance, containerName)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The list is not sorted, keep the claims in a stable order
+	sort.Slice(pvcs.Items, func(i, j int) bool {
+		return pvcs.Items[i].Name < pvcs.Items[j].Name
+	})
+
+	desired := toVolumeClaimTemplate(appInstance, volume)
+	for _, existing := range pvcs.Items {
+		if !isReplicaClaim(existing.Name, volume, containerName) {
+			continue
+		}
+
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        existing.Name,
+				Namespace:   existing.Namespace,
+				Labels:      typed.Concat(desired.Labels, selectorMatchLabels(appInstance, containerName)),
+				Annotations: typed.Concat(desired.Annotations, map[string]string{apply.AnnotationCreate: "false"}),
+			},
+			Spec: *existing.Spec.DeepCopy(),
+		}
+		pvc.Spec.Resources.Requests = desired.Spec.Resources.Requests.DeepCopy()
+
+		if err := resizePVC(req, pvc); err != nil {
+			return nil, err
+		}
+		result = append(result, pvc)
+	}
+
+	pv, err := migratedPV(req, appInstance, containerName, volume)
+	if err != nil || pv == nil {
+		return result, err
+	}
+
+	pvc := desired.DeepCopy()
+	pvc.Name = replicaClaimName(volume, containerName, 0)
+	pvc.Namespace = appInstance.Status.Namespace
+	pvc.Labels = typed.Concat(desired.Labels, selectorMatchLabels(appInstance, containerName))
+	pvc.Spec.VolumeName = pv.Name
+	// The claim can't bind a volume smaller than its request, it is expanded once it is bound
+	if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok && capacity.Cmp(pvc.Spec.Resources.Requests[corev1.ResourceStorage]) < 0 {
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = capacity
+	}
+	return append(result, pvc), nil
+}
+
+func toVolumeClaimTemplate(appInstance *v1.AppInstance, volume string) corev1.PersistentVolumeClaim {
+	var (
+		volumeRequest    = appInstance.Status.AppSpec.Volumes[volume]
+		volumeBinding, _ = isBind(appInstance, volume)
+	)
+
+	pvc := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   volume,
+			Labels: volumeLabels(appInstance, volume, volumeRequest),
+			Annotations: labels.GatherScoped(volume, v1.LabelTypeVolume, appInstance.Status.AppSpec.Annotations,
+				volumeRequest.Annotations, appInstance.Spec.Annotations),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: translateAccessModes(volumeRequest.AccessModes),
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: *v1.DefaultSize,
+				},
+			},
+		},
+	}
+
+	if volumeRequest.Class != "" {
+		pvc.Spec.StorageClassName = &volumeRequest.Class
+	}
+	if volumeRequest.Size != "" {
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.MustParseResourceQuantity(volumeRequest.Size)
+	}
+
+	if len(volumeBinding.AccessModes) > 0 {
+		pvc.Spec.AccessModes = translateAccessModes(volumeBinding.AccessModes)
+	}
+	if volumeBinding.Class != "" {
+		pvc.Spec.StorageClassName = &volumeBinding.Class
+	}
+	if volumeBinding.Size != "" {
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.MustParseResourceQuantity(volumeBinding.Size)
+	}
+
+	return pvc
+}
+
+// toStatefulSet runs the pods of the deployment of a stateful container as a StatefulSet, so each replica gets a
+// stable hostname and its own claim for each volume of the container. The claims are named
+// <volume>-<container>-<replica>.
+func toStatefulSet(req router.Request, appInstance *v1.AppInstance, dep *appsv1.Deployment, container v1.Container) (*appsv1.StatefulSet, error) {
+	var claimTemplates []corev1.PersistentVolumeClaim
+	for _, volume := range templatedVolumes(appInstance, container) {
+		claimTemplates = append(claimTemplates, toVolumeClaimTemplate(appInstance, volume))
+	}
+
+	// The claim templates of a StatefulSet can not be changed once it is created
+	existing := &appsv1.StatefulSet{}
+	if err := req.Get(existing, dep.Namespace, dep.Name); err == nil {
+		claimTemplates = existing.Spec.VolumeClaimTemplates
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	// Replicas are not started until the first replica can keep the data of the volumes from before the container
+	// became stateful
+	replicas := dep.Spec.Replicas
+	if migrating, err := isMigrating(req, appInstance, dep.Name, container); err != nil {
+		return nil, err
+	} else if migrating {
+		replicas = new(int32)
+	}
+
+	template := *dep.Spec.Template.DeepCopy()
+	template.Spec.Hostname = ""
+
+	templated := sets.NewString()
+	for _, claimTemplate := range claimTemplates {
+		templated.Insert(claimTemplate.Name)
+	}
+	template.Spec.Volumes = nil
+	for _, volume := range dep.Spec.Template.Spec.Volumes {
+		if !templated.Has(volume.Name) {
+			template.Spec.Volumes = append(template.Spec.Volumes, volume)
+		}
+	}
+
+	// Replicas are replaced in place, so only maxUnavailable of the rollout applies. It needs the
+	// MaxUnavailableStatefulSet feature gate, without it replicas are replaced one at a time.
+	var updateStrategy appsv1.StatefulSetUpdateStrategy
+	if container.Rollout != nil && container.Rollout.MaxUnavailable != nil {
+		updateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+				MaxUnavailable: container.Rollout.MaxUnavailable,
+			},
+		}
+	}
+
+	return &appsv1.StatefulSet{
+		ObjectMeta: dep.ObjectMeta,
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:             replicas,
+			Selector:             dep.Spec.Selector,
+			Template:             template,
+			VolumeClaimTemplates: claimTemplates,
+			ServiceName:          headlessServiceName(dep.Name),
+			MinReadySeconds:      dep.Spec.MinReadySeconds,
+			UpdateStrategy:       updateStrategy,
+		},
+	}, nil
+}
+
+// toHeadlessService gives each replica of a StatefulSet a DNS name of <container>-<replica>.<container>-headless.
+// Replicas are published before they are ready so they can find each other while starting.
+func toHeadlessService(appInstance *v1.AppInstance, dep *appsv1.Deployment, container v1.Container) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        headlessServiceName(dep.Name),
+			Namespace:   appInstance.Status.Namespace,
+			Labels:      containerLabels(appInstance, container, dep.Name),
+			Annotations: containerAnnotations(appInstance, container, dep.Name),
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                corev1.ClusterIPNone,
+			Selector:                 dep.Spec.Selector.MatchLabels,
+			PublishNotReadyAddresses: true,
+		},
+	}
+}
+
+// statefulSetAsDeployment returns the replica counts of the StatefulSet in the shape of a deployment, so status
+// checks can treat both the same
+func statefulSetAsDeployment(sts appsv1.StatefulSet) appsv1.Deployment {
+	return appsv1.Deployment{
+		ObjectMeta: sts.ObjectMeta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: sts.Spec.Replicas,
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: sts.Status.ObservedGeneration,
+			Replicas:           sts.Status.Replicas,
+			ReadyReplicas:      sts.Status.ReadyReplicas,
+			UpdatedReplicas:    sts.Status.UpdatedReplicas,
+			AvailableReplicas:  sts.Status.AvailableReplicas,
+		},
+	}
+}
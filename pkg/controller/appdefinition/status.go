@@ -17,6 +17,7 @@ import (
 	"github.com/acorn-io/baaah/pkg/router"
 	"github.com/acorn-io/baaah/pkg/typed"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -212,6 +213,7 @@ func AppStatus(req router.Request, resp router.Response) error {
 		app  = req.Object.(*v1.AppInstance)
 		cond = condition.Setter(app, resp, v1.AppInstanceConditionContainers)
 		deps = &appsv1.DeploymentList{}
+		hpas = &autoscalingv2.HorizontalPodAutoscalerList{}
 	)
 
 	cfg, err := config.Get(req.Ctx, req.Client)
@@ -230,6 +232,39 @@ func AppStatus(req router.Request, resp router.Response) error {
 		return err
 	}
 
+	statefulSets := &appsv1.StatefulSetList{}
+	err = req.List(statefulSets, &kclient.ListOptions{
+		Namespace: app.Status.Namespace,
+		LabelSelector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornManaged: "true",
+			labels.AcornAppName: app.Name,
+		}),
+	})
+	if err != nil {
+		return err
+	}
+	for _, sts := range statefulSets.Items {
+		deps.Items = append(deps.Items, statefulSetAsDeployment(sts))
+	}
+
+	err = req.List(hpas, &kclient.ListOptions{
+		Namespace: app.Status.Namespace,
+		LabelSelector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornManaged: "true",
+			labels.AcornAppName: app.Name,
+		}),
+	})
+	if err != nil {
+		return err
+	}
+
+	desiredReplicas := map[string]int32{}
+	for _, hpa := range hpas.Items {
+		if hpa.Status.DesiredReplicas > 0 {
+			desiredReplicas[hpa.Labels[labels.AcornContainerName]] = hpa.Status.DesiredReplicas
+		}
+	}
+
 	notJob, err := klabels.NewRequirement(labels.AcornContainerName, selection.Exists, nil)
 	if err != nil {
 		return err
@@ -262,6 +297,14 @@ func AppStatus(req router.Request, resp router.Response) error {
 		status.Ready = dep.Status.ReadyReplicas
 		status.ReadyDesired = dep.Status.Replicas
 		status.UpToDate = dep.Status.UpdatedReplicas
+		status.CurrentReplicas = dep.Status.Replicas
+		status.DesiredReplicas = 1
+		if dep.Spec.Replicas != nil {
+			status.DesiredReplicas = *dep.Spec.Replicas
+		}
+		if replicas, ok := desiredReplicas[containerName]; ok {
+			status.DesiredReplicas = replicas
+		}
 		status.Created = true
 		container[containerName] = status
 
@@ -316,6 +359,11 @@ func AppStatus(req router.Request, resp router.Response) error {
 
 func containerMessages(pod *corev1.Pod, status []corev1.ContainerStatus) (message []string, isTransition bool) {
 	for _, container := range status {
+		if msg, ok := seedMessage(pod, container); ok {
+			isTransition = true
+			message = append(message, podName(pod)+" "+msg)
+			continue
+		}
 		if container.State.Waiting != nil && container.State.Waiting.Reason != "" {
 			isTransition = true
 			if container.State.Waiting.Message == "" {
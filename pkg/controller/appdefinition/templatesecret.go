@@ -0,0 +1,129 @@
+package appdefinition
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"text/template"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/images"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// templateApp is the app metadata available to templates as .App
+type templateApp struct {
+	Name            string
+	Namespace       string
+	TargetNamespace string
+}
+
+type templateData struct {
+	App       templateApp
+	Endpoints []v1.Endpoint
+}
+
+// renderGoTemplate renders a value of a template secret that uses the go engine. The first error returned by a
+// function that reads another secret is returned as is, so waiting for a secret or job is reported like it is for
+// ${secret://} references.
+func renderGoTemplate(secrets map[string]*corev1.Secret, req router.Request, appInstance *v1.AppInstance, tag name.Reference, key, text string) (string, error) {
+	var secretErr error
+
+	funcs := template.FuncMap{
+		"secret": func(name, key string) (string, error) {
+			secret, err := getOrCreateSecret(secrets, req, appInstance, name)
+			if err != nil {
+				if secretErr == nil {
+					secretErr = err
+				}
+				return "", err
+			}
+			return string(secret.Data[key]), nil
+		},
+		"image": func(name string) (string, error) {
+			digest, ok := appInstance.Status.AppImage.ImageData.Images[name]
+			if !ok {
+				return "", fmt.Errorf("failed to find image %s", name)
+			}
+			return images.ResolveTag(tag, digest.Image), nil
+		},
+		"endpoint": func(target string) string {
+			for _, endpoint := range appInstance.Status.Endpoints {
+				if endpoint.Target == target && !endpoint.Pending {
+					return endpoint.Address
+				}
+			}
+			return ""
+		},
+		"b64enc": func(s string) string {
+			return base64.StdEncoding.EncodeToString([]byte(s))
+		},
+		"b64dec": func(s string) (string, error) {
+			data, err := base64.StdEncoding.DecodeString(s)
+			return string(data), err
+		},
+		"urlEncode":     url.QueryEscape,
+		"urlPathEscape": url.PathEscape,
+		"toJson": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"default": func(def, v any) any {
+			if isEmpty(v) {
+				return def
+			}
+			return v
+		},
+		"required": func(msg string, v any) (any, error) {
+			if isEmpty(v) {
+				return nil, errors.New(msg)
+			}
+			return v, nil
+		},
+		"trim":  strings.TrimSpace,
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+	}
+
+	t, err := template.New(key).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid template %s: %w", key, err)
+	}
+
+	out := &strings.Builder{}
+	err = t.Execute(out, templateData{
+		App: templateApp{
+			Name:            appInstance.Name,
+			Namespace:       appInstance.Namespace,
+			TargetNamespace: appInstance.Status.Namespace,
+		},
+		Endpoints: appInstance.Status.Endpoints,
+	})
+	if secretErr != nil {
+		return "", secretErr
+	} else if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return value.IsNil()
+	default:
+		return value.IsZero()
+	}
+}
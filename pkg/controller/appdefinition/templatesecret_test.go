@@ -0,0 +1,17 @@
+package appdefinition
+
+import (
+	"testing"
+
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+)
+
+func TestGoTemplateFunctions(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/secret-template-go-functions", CreateSecrets)
+}
+
+// Missing secrets are reported like they are for ${secret://} references, other errors by secret
+func TestGoTemplateErrors(t *testing.T) {
+	tester.DefaultTest(t, scheme.Scheme, "testdata/secret-template-go-errors", CreateSecrets)
+}
@@ -0,0 +1,368 @@
+package appdefinition
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/baaah/pkg/router"
+	"github.com/acorn-io/baaah/pkg/typed"
+	"github.com/rancher/wrangler/pkg/data/convert"
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// tlsCAKey holds the certificate of the CA in tls secrets, so clients can verify the certificates of other services
+	tlsCAKey       = "ca.crt"
+	caScopeApp     = "app"
+	caScopeProject = "project"
+	caValidity     = 10 * 365 * 24 * time.Hour
+)
+
+type tlsParams struct {
+	commonName string
+	dnsNames   []string
+	ips        []net.IP
+	algorithm  string
+	validity   time.Duration
+	caScope    string
+}
+
+// getTLSParams reads the params of a tls secret. The SANs default to the names the containers of the app are reachable
+// at, and the common name to the first SAN.
+func getTLSParams(appInstance *v1.AppInstance, secretRef v1.Secret) (result tlsParams, err error) {
+	result.algorithm = convert.ToString(secretRef.Params["algorithm"])
+	result.caScope = convert.ToString(secretRef.Params["ca"])
+	if result.caScope != caScopeApp && result.caScope != caScopeProject {
+		return result, fmt.Errorf("invalid ca %s: must be %s or %s", result.caScope, caScopeApp, caScopeProject)
+	}
+	result.commonName = convert.ToString(secretRef.Params["commonName"])
+
+	validity := convert.ToString(secretRef.Params["validity"])
+	result.validity, err = time.ParseDuration(validity)
+	if err != nil {
+		return result, fmt.Errorf("invalid validity %s: %w", validity, err)
+	} else if result.validity <= 0 {
+		return result, fmt.Errorf("invalid validity %s: must be greater than zero", validity)
+	}
+
+	sans := convert.ToStringSlice(secretRef.Params["sans"])
+	if len(sans) == 0 {
+		for _, name := range typed.SortedKeys(appInstance.Status.AppSpec.Containers) {
+			sans = append(sans, name)
+			if appInstance.Status.Namespace != "" {
+				sans = append(sans, name+"."+appInstance.Status.Namespace, name+"."+appInstance.Status.Namespace+".svc")
+			}
+		}
+	}
+	for _, san := range sans {
+		if ip := net.ParseIP(san); ip != nil {
+			result.ips = append(result.ips, ip)
+		} else {
+			result.dnsNames = append(result.dnsNames, san)
+		}
+	}
+
+	if result.commonName == "" && len(sans) > 0 {
+		result.commonName = sans[0]
+	}
+
+	return result, nil
+}
+
+func generateTLS(req router.Request, appInstance *v1.AppInstance, secretName string, secretRef v1.Secret, existing *corev1.Secret) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: secretName + "-",
+			Namespace:    appInstance.Namespace,
+			Labels:       labelsForSecret(secretName, appInstance, secretRef),
+			Annotations:  annotationsForSecret(secretName, appInstance, secretRef),
+		},
+		Data: seedData(existing, secretRef.Data, corev1.TLSCertKey, corev1.TLSPrivateKeyKey, tlsCAKey),
+		Type: v1.SecretTypeTLS,
+	}
+
+	params, err := getTLSParams(appInstance, secretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	caCert, caKey, caPEM, err := getOrCreateCA(req, appInstance, params.caScope)
+	if err != nil {
+		return nil, err
+	}
+
+	if needsCertificate(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], caCert, params, timeNow()) {
+		certPEM, keyPEM, err := issueCertificate(caCert, caKey, params, timeNow())
+		if err != nil {
+			return nil, err
+		}
+		secret.Data[corev1.TLSCertKey] = certPEM
+		secret.Data[corev1.TLSPrivateKeyKey] = keyPEM
+	}
+	secret.Data[tlsCAKey] = caPEM
+
+	return updateOrCreate(req, existing, secret)
+}
+
+// getOrCreateCA returns the CA that signs the tls secrets of an app. Each app has its own CA unless the scope is project,
+// in which case all apps in the namespace of the app share one. CA secrets are not acorn secrets, so they aren't
+// listed or exposed by the API.
+func getOrCreateCA(req router.Request, appInstance *v1.AppInstance, scope string) (*x509.Certificate, crypto.Signer, []byte, error) {
+	caLabels := map[string]string{
+		labels.AcornManaged:              "true",
+		labels.AcornCertificateAuthority: scope,
+	}
+	commonName := appInstance.Namespace + " CA"
+	if scope == caScopeApp {
+		caLabels[labels.AcornAppName] = appInstance.Name
+		commonName = appInstance.Namespace + "/" + appInstance.Name + " CA"
+	}
+
+	var secrets corev1.SecretList
+	err := req.List(&secrets, &kclient.ListOptions{
+		Namespace:     appInstance.Namespace,
+		LabelSelector: klabels.SelectorFromSet(caLabels),
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var secret *corev1.Secret
+	if len(secrets.Items) > 0 {
+		sort.Slice(secrets.Items, func(i, j int) bool {
+			return secrets.Items[i].UID < secrets.Items[j].UID
+		})
+		secret = &secrets.Items[0]
+	} else {
+		certPEM, keyPEM, err := newCA(commonName, timeNow())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "acorn-ca-",
+				Namespace:    appInstance.Namespace,
+				Labels:       caLabels,
+			},
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: keyPEM,
+			},
+			Type: corev1.SecretTypeTLS,
+		}
+		if err := req.Client.Create(req.Ctx, secret); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid CA certificate in secret %s: %w", secret.Name, err)
+	}
+	key, err := parsePrivateKey(secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid CA key in secret %s: %w", secret.Name, err)
+	}
+	return cert, key, secret.Data[corev1.TLSCertKey], nil
+}
+
+func newCA(commonName string, now time.Time) ([]byte, []byte, error) {
+	key, err := generatePrivateKey("ecdsa")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encodeCertificate(der, key)
+}
+
+// issueCertificate issues a certificate that can be used as both a server and a client certificate, so services of an
+// app can use mTLS.
+func issueCertificate(caCert *x509.Certificate, caKey crypto.Signer, params tlsParams, now time.Time) ([]byte, []byte, error) {
+	key, err := generatePrivateKey(params.algorithm)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyUsage := x509.KeyUsageDigitalSignature
+	if params.algorithm == "rsa" {
+		keyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	notAfter := now.Add(params.validity)
+	if notAfter.After(caCert.NotAfter) {
+		notAfter = caCert.NotAfter
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: params.commonName},
+		DNSNames:     params.dnsNames,
+		IPAddresses:  params.ips,
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     notAfter,
+		KeyUsage:     keyUsage,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, key.Public(), caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encodeCertificate(der, key)
+}
+
+// needsCertificate returns true if there is no certificate yet, it is due for renewal, or it no longer matches the CA
+// or the params of the secret.
+func needsCertificate(certPEM, keyPEM []byte, caCert *x509.Certificate, params tlsParams, now time.Time) bool {
+	cert, err := parseCertificate(certPEM)
+	if err != nil {
+		return true
+	}
+	key, err := parsePrivateKey(keyPEM)
+	if err != nil || !publicKeyEqual(key.Public(), cert.PublicKey) || keyAlgorithm(key) != params.algorithm {
+		return true
+	}
+	if cert.CheckSignatureFrom(caCert) != nil {
+		return true
+	}
+	if !now.Before(renewalTime(cert)) {
+		return true
+	}
+	if cert.Subject.CommonName != params.commonName || !slices.Equal(cert.DNSNames, params.dnsNames) ||
+		!slices.EqualFunc(cert.IPAddresses, params.ips, net.IP.Equal) {
+		return true
+	}
+	return false
+}
+
+// renewalTime is when two thirds of the lifetime of the certificate have passed
+func renewalTime(cert *x509.Certificate) time.Time {
+	return cert.NotBefore.Add(cert.NotAfter.Sub(cert.NotBefore) * 2 / 3)
+}
+
+// certificateRenewalTime returns when the certificate of a tls secret has to be re-issued
+func certificateRenewalTime(secret *corev1.Secret) (time.Time, bool) {
+	cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return renewalTime(cert), true
+}
+
+func generatePrivateKey(algorithm string) (crypto.Signer, error) {
+	switch algorithm {
+	case "ecdsa":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "rsa":
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case "ed25519":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm %s", algorithm)
+	}
+}
+
+func keyAlgorithm(key crypto.Signer) string {
+	switch key.(type) {
+	case *ecdsa.PrivateKey:
+		return "ecdsa"
+	case *rsa.PrivateKey:
+		return "rsa"
+	case ed25519.PrivateKey:
+		return "ed25519"
+	}
+	return ""
+}
+
+func publicKeyEqual(a, b crypto.PublicKey) bool {
+	aBytes, err := x509.MarshalPKIXPublicKey(a)
+	if err != nil {
+		return false
+	}
+	bBytes, err := x509.MarshalPKIXPublicKey(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aBytes, bBytes)
+}
+
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func encodeCertificate(der []byte, key crypto.Signer) ([]byte, []byte, error) {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no private key found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
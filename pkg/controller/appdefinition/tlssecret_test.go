@@ -0,0 +1,137 @@
+package appdefinition
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/router/tester"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// createSecretsFromDir runs CreateSecrets for the input in dir and returns the response, the certificates it issues are
+// random so they are checked instead of compared to testdata
+func createSecretsFromDir(t *testing.T, dir string) *tester.Response {
+	t.Helper()
+
+	harness, input, err := tester.FromDir(scheme.Scheme, dir)
+	require.NoError(t, err)
+
+	req := tester.NewRequest(t, scheme.Scheme, input, harness.Existing...)
+	resp := &tester.Response{Client: req.Client.(*tester.Client)}
+	require.NoError(t, CreateSecrets(req, resp))
+	return resp
+}
+
+func TestTLS(t *testing.T) {
+	timeNow = func() time.Time {
+		return time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)
+	}
+	defer func() {
+		timeNow = time.Now
+	}()
+
+	// The certificate in testdata was issued a day earlier, it is kept and re-issued after 60 of its 90 days
+	harness, input, err := tester.FromDir(scheme.Scheme, "testdata/secret-tls/existing")
+	require.NoError(t, err)
+	harness.ExpectedDelay = 59*24*time.Hour - 20*time.Second
+	_, err = harness.InvokeFunc(t, input, CreateSecrets)
+	require.NoError(t, err)
+}
+
+func TestTLS_Gen(t *testing.T) {
+	resp := createSecretsFromDir(t, "testdata/secret-tls/generate")
+
+	assert.Len(t, resp.Client.Created, 2)
+	assert.Len(t, resp.Collected, 2)
+
+	ca := resp.Client.Created[0].(*corev1.Secret)
+	assert.Equal(t, corev1.SecretTypeTLS, ca.Type)
+	assert.Equal(t, "app", ca.Labels[labels.AcornCertificateAuthority])
+	assert.Equal(t, "app-name", ca.Labels[labels.AcornAppName])
+
+	secret := resp.Client.Created[1].(*corev1.Secret)
+	assert.Equal(t, v1.SecretTypeTLS, secret.Type)
+	assert.Equal(t, "tls", secret.Labels[labels.AcornSecretName])
+	assert.Equal(t, ca.Data[corev1.TLSCertKey], secret.Data[tlsCAKey])
+
+	cert, err := parseCertificate(secret.Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	assert.Equal(t, "api", cert.Subject.CommonName)
+	assert.Equal(t, []string{"api", "api.app-target-ns", "api.app-target-ns.svc", "web", "web.app-target-ns",
+		"web.app-target-ns.svc"}, cert.DNSNames)
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(secret.Data[tlsCAKey])
+	for _, usage := range []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth} {
+		_, err = cert.Verify(x509.VerifyOptions{
+			DNSName:   "web.app-target-ns.svc",
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{usage},
+		})
+		assert.NoError(t, err)
+	}
+
+	// The app is requeued to re-issue the certificate after 60 of its 90 days
+	assert.InDelta(t, (60 * 24 * time.Hour).Hours(), resp.Delay.Hours(), 1)
+}
+
+func TestTLS_GenProjectCA(t *testing.T) {
+	resp := createSecretsFromDir(t, "testdata/secret-tls/project-ca")
+
+	assert.Len(t, resp.Client.Created, 2)
+	ca := resp.Client.Created[0].(*corev1.Secret)
+	assert.Equal(t, "project", ca.Labels[labels.AcornCertificateAuthority])
+	assert.Empty(t, ca.Labels[labels.AcornAppName])
+
+	cert, err := parseCertificate(resp.Client.Created[1].(*corev1.Secret).Data[corev1.TLSCertKey])
+	require.NoError(t, err)
+	assert.Equal(t, "api.example.com", cert.Subject.CommonName)
+	assert.Equal(t, []string{"api.example.com"}, cert.DNSNames)
+	assert.Equal(t, "10.0.0.10", cert.IPAddresses[0].String())
+	assert.Equal(t, x509.RSA, cert.PublicKeyAlgorithm)
+}
+
+func TestNeedsCertificate(t *testing.T) {
+	_, input, err := tester.FromDir(scheme.Scheme, "testdata/secret-tls/generate")
+	require.NoError(t, err)
+	app := input.(*v1.AppInstance)
+
+	now := time.Now()
+	params, err := getTLSParams(app, app.Status.AppSpec.Secrets["tls"])
+	require.NoError(t, err)
+
+	caPEM, caKeyPEM, err := newCA("test CA", now)
+	require.NoError(t, err)
+	caCert, err := parseCertificate(caPEM)
+	require.NoError(t, err)
+	caKey, err := parsePrivateKey(caKeyPEM)
+	require.NoError(t, err)
+
+	certPEM, keyPEM, err := issueCertificate(caCert, caKey, params, now)
+	require.NoError(t, err)
+
+	assert.True(t, needsCertificate(nil, nil, caCert, params, now))
+	assert.False(t, needsCertificate(certPEM, keyPEM, caCert, params, now))
+	assert.False(t, needsCertificate(certPEM, keyPEM, caCert, params, now.Add(59*24*time.Hour)))
+	assert.True(t, needsCertificate(certPEM, keyPEM, caCert, params, now.Add(61*24*time.Hour)))
+
+	changed := params
+	changed.dnsNames = append([]string{"other"}, params.dnsNames...)
+	assert.True(t, needsCertificate(certPEM, keyPEM, caCert, changed, now))
+
+	changed = params
+	changed.algorithm = "ed25519"
+	assert.True(t, needsCertificate(certPEM, keyPEM, caCert, changed, now))
+
+	otherCAPEM, _, err := newCA("other CA", now)
+	require.NoError(t, err)
+	otherCA, err := parseCertificate(otherCAPEM)
+	require.NoError(t, err)
+	assert.True(t, needsCertificate(certPEM, keyPEM, otherCA, params, now))
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/acorn-io/baaah/pkg/uncached"
 	name2 "github.com/rancher/wrangler/pkg/name"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	klabels "k8s.io/apimachinery/pkg/labels"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -81,7 +83,29 @@ func lookupExistingPV(req router.Request, appInstance *v1.AppInstance, volumeNam
 	}
 }
 
+// lookupBindPV returns the name of the volume to bind. Volumes restored from a snapshot are bound by the name they
+// were restored as.
+func lookupBindPV(req router.Request, appInstance *v1.AppInstance, volumeName string) (string, error) {
+	var pv corev1.PersistentVolumeList
+	err := req.List(uncached.List(&pv), &kclient.ListOptions{
+		LabelSelector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornManaged:        "true",
+			labels.AcornAppNamespace:   appInstance.Namespace,
+			labels.AcornRestoredVolume: volumeName,
+		}),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(pv.Items) == 1 {
+		return pv.Items[0].Name, nil
+	}
+	return volumeName, nil
+}
+
 func toPVCs(req router.Request, appInstance *v1.AppInstance) (result []kclient.Object, _ error) {
+	templated := statefulVolumes(appInstance)
 	for _, entry := range typed.Sorted(appInstance.Status.AppSpec.Volumes) {
 		volume, volumeRequest := entry.Key, entry.Value
 
@@ -95,6 +119,16 @@ func toPVCs(req router.Request, appInstance *v1.AppInstance) (result []kclient.O
 			continue
 		}
 
+		// The claims of stateful volumes are created for each replica by their StatefulSet
+		if containerName, ok := templated[volume]; ok {
+			pvcs, err := toReplicaPVCs(req, appInstance, containerName, volume)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, pvcs...)
+			continue
+		}
+
 		pvc := corev1.PersistentVolumeClaim{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      volume,
@@ -112,8 +146,12 @@ func toPVCs(req router.Request, appInstance *v1.AppInstance) (result []kclient.O
 		}
 
 		if bind {
+			pvName, err := lookupBindPV(req, appInstance, volumeBinding.Volume)
+			if err != nil {
+				return nil, err
+			}
 			pvc.Name = bindName(volume)
-			pvc.Spec.VolumeName = volumeBinding.Volume
+			pvc.Spec.VolumeName = pvName
 			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.MinSize
 		} else {
 			if volumeRequest.Class != "" {
@@ -146,11 +184,73 @@ func toPVCs(req router.Request, appInstance *v1.AppInstance) (result []kclient.O
 			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *v1.MustParseResourceQuantity(volumeBinding.Size)
 		}
 
+		if err := resizePVC(req, &pvc); err != nil {
+			return nil, err
+		}
+
 		result = append(result, &pvc)
 	}
 	return
 }
 
+// resizePVC keeps the requested size of an existing claim unless the claim is expanded and its storage class allows
+// expansion. Claims can't shrink and the API server rejects expanding a claim of a class that doesn't allow it, both
+// are reported on the volume instead.
+func resizePVC(req router.Request, pvc *corev1.PersistentVolumeClaim) error {
+	existing := &corev1.PersistentVolumeClaim{}
+	if err := req.Get(existing, pvc.Namespace, pvc.Name); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	current, ok := existing.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return nil
+	}
+
+	// Only bound claims can be expanded, pending claims are expanded once they are bound
+	desired := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	var msg string
+	if cmp := desired.Cmp(current); cmp < 0 {
+		msg = fmt.Sprintf("can not shrink volume from %s to %s, volumes can only be expanded", current.String(), desired.String())
+	} else if cmp == 0 || existing.Status.Phase != corev1.ClaimBound {
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = current
+		return nil
+	} else if m, err := checkExpansion(req, existing, desired); err != nil || m == "" {
+		return err
+	} else {
+		msg = m
+	}
+
+	pvc.Spec.Resources.Requests[corev1.ResourceStorage] = current
+	if pvc.Annotations == nil {
+		pvc.Annotations = map[string]string{}
+	}
+	pvc.Annotations[labels.AcornVolumeResizeError] = msg
+	return nil
+}
+
+// checkExpansion returns why the claim can't be expanded to the desired size, or an empty string if it can
+func checkExpansion(req router.Request, pvc *corev1.PersistentVolumeClaim, desired resource.Quantity) (string, error) {
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return fmt.Sprintf("can not expand volume to %s, the volume has no storage class", desired.String()), nil
+	}
+
+	className := *pvc.Spec.StorageClassName
+	var class storagev1.StorageClass
+	if err := req.Get(&class, "", className); apierrors.IsNotFound(err) {
+		return fmt.Sprintf("can not expand volume to %s, storage class %s does not exist", desired.String(), className), nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if class.AllowVolumeExpansion == nil || !*class.AllowVolumeExpansion {
+		return fmt.Sprintf("can not expand volume to %s, storage class %s does not allow volume expansion", desired.String(), className), nil
+	}
+	return "", nil
+}
+
 func volumeLabels(appInstance *v1.AppInstance, volume string, volumeRequest v1.VolumeRequest) map[string]string {
 	labelMap := map[string]string{
 		labels.AcornAppName:      appInstance.Name,
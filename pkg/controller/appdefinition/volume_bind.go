@@ -6,11 +6,13 @@ import (
 	"github.com/acorn-io/baaah/pkg/router"
 	corev1 "k8s.io/api/core/v1"
 	apierror "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func ReleaseVolume(req router.Request, resp router.Response) error {
 	pv := req.Object.(*corev1.PersistentVolume)
 	if pv.Labels[labels.AcornManaged] == "true" &&
+		pv.Labels[labels.AcornAppName] != "" &&
 		pv.Status.Phase == corev1.VolumeReleased &&
 		pv.Spec.ClaimRef != nil {
 
@@ -26,8 +28,13 @@ func ReleaseVolume(req router.Request, resp router.Response) error {
 
 		pvc := &corev1.PersistentVolumeClaim{}
 		err = req.Get(pvc, app.Status.Namespace, pv.Labels[labels.AcornVolumeName])
-		if apierror.IsNotFound(err) {
-			return nil
+		if apierror.IsNotFound(err) || (err == nil && !pvc.DeletionTimestamp.IsZero()) {
+			// The claim of the volume can be renamed, like the claim of the first replica when a container becomes
+			// stateful
+			pvc, err = claimForVolume(req, app.Status.Namespace, pv.Name)
+			if err != nil || pvc == nil {
+				return err
+			}
 		} else if err != nil {
 			return err
 		}
@@ -38,3 +45,16 @@ func ReleaseVolume(req router.Request, resp router.Response) error {
 	}
 	return nil
 }
+
+func claimForVolume(req router.Request, namespace, pvName string) (*corev1.PersistentVolumeClaim, error) {
+	var pvcs corev1.PersistentVolumeClaimList
+	if err := req.List(&pvcs, &kclient.ListOptions{Namespace: namespace}); err != nil {
+		return nil, err
+	}
+	for _, pvc := range pvcs.Items {
+		if pvc.Spec.VolumeName == pvName && pvc.DeletionTimestamp.IsZero() {
+			return &pvc, nil
+		}
+	}
+	return nil, nil
+}
@@ -9,10 +9,15 @@ import (
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/labels"
 	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/baaah/pkg/apply"
 	"github.com/acorn-io/baaah/pkg/router/tester"
 	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func TestVolumeController(t *testing.T) {
@@ -167,3 +172,194 @@ func TestVolumeLabelsAnnotations(t *testing.T) {
 	assert.Contains(t, pvc2.Annotations, "globalfromacornfilea")
 	assert.NotContains(t, pvc2.Annotations, "vol1fromacornfilea")
 }
+
+func TestStatefulVolumes(t *testing.T) {
+	appInstance := &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name",
+			Namespace: "app-ns",
+		},
+		Spec: v1.AppInstanceSpec{
+			Volumes: []v1.VolumeBinding{
+				{Target: "bound", Volume: "existing"},
+			},
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-target-ns",
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"db": {
+						Stateful: true,
+						Dirs: map[string]v1.VolumeMount{
+							"/var/lib/data": {Volume: "data"},
+							"/backup":       {Volume: "bound"},
+						},
+					},
+					"web": {
+						Dirs: map[string]v1.VolumeMount{
+							"/cache": {Volume: "cache"},
+						},
+					},
+				},
+				Volumes: map[string]v1.VolumeRequest{
+					"data":  {Class: "expandable", Size: "20G"},
+					"bound": {},
+					"cache": {},
+				},
+			},
+		},
+	}
+
+	replicaClaim := func(name string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "app-target-ns",
+				Labels:    selectorMatchLabels(appInstance, "db"),
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &[]string{"expandable"}[0],
+				VolumeName:       "pv-" + name,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse("10G"),
+					},
+				},
+			},
+			Status: corev1.PersistentVolumeClaimStatus{
+				Phase: corev1.ClaimBound,
+			},
+		}
+	}
+
+	req := tester.NewRequest(t, scheme.Scheme, appInstance,
+		replicaClaim("data-db-0"),
+		replicaClaim("data-db-1"),
+		&storagev1.StorageClass{
+			ObjectMeta:           metav1.ObjectMeta{Name: "expandable"},
+			AllowVolumeExpansion: &[]bool{true}[0],
+		})
+
+	pvcs, err := toPVCs(req, appInstance)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, obj := range pvcs {
+		pvc := obj.(*corev1.PersistentVolumeClaim)
+		names = append(names, pvc.Name)
+		if strings.HasPrefix(pvc.Name, "data-db-") {
+			// Claims of replicas are expanded in place and never created
+			assert.Equal(t, resource.MustParse("20G"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+			assert.Equal(t, "pv-"+pvc.Name, pvc.Spec.VolumeName)
+			assert.Equal(t, "false", pvc.Annotations[apply.AnnotationCreate])
+		}
+	}
+	assert.Equal(t, []string{bindName("bound"), "cache", "data-db-0", "data-db-1"}, names)
+}
+
+func TestStatefulMigration(t *testing.T) {
+	appInstance := &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-name",
+			Namespace: "app-ns",
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: "app-target-ns",
+			AppSpec: v1.AppSpec{
+				Containers: map[string]v1.Container{
+					"db": {
+						Stateful: true,
+						Scale:    &[]int32{2}[0],
+						Dirs: map[string]v1.VolumeMount{
+							"/var/lib/data": {Volume: "data"},
+						},
+					},
+				},
+				Volumes: map[string]v1.VolumeRequest{
+					"data": {Size: "20G"},
+				},
+			},
+		},
+	}
+
+	// The claim of the volume from before the container became stateful
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "data",
+			Namespace: "app-target-ns",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			VolumeName: "pv-data",
+		},
+	}
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pv-data",
+			Labels: map[string]string{
+				labels.AcornManaged:      "true",
+				labels.AcornAppName:      "app-name",
+				labels.AcornAppNamespace: "app-ns",
+				labels.AcornVolumeName:   "data",
+			},
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("10G"),
+			},
+		},
+	}
+
+	// The first replica is bound to the existing volume before any replica is started
+	req := tester.NewRequest(t, scheme.Scheme, appInstance, claim, pv)
+	pvcs, err := toPVCs(req, appInstance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, pvcs, 1) {
+		pvc := pvcs[0].(*corev1.PersistentVolumeClaim)
+		assert.Equal(t, "data-db-0", pvc.Name)
+		assert.Equal(t, "pv-data", pvc.Spec.VolumeName)
+		assert.Equal(t, resource.MustParse("10G"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+	}
+
+	sts, err := toStatefulSet(req, appInstance, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "app-target-ns"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &[]int32{2}[0]},
+	}, appInstance.Status.AppSpec.Containers["db"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(0), *sts.Spec.Replicas)
+
+	// Once the old claim is deleted the released volume is bound to the claim of the first replica
+	now := metav1.Now()
+	claim.DeletionTimestamp = &now
+	pv.Status.Phase = corev1.VolumeReleased
+	pv.Spec.ClaimRef = &corev1.ObjectReference{Name: "data", Namespace: "app-target-ns"}
+	replicaClaim := pvcs[0].(*corev1.PersistentVolumeClaim)
+
+	h := tester.Harness{
+		Scheme:   scheme.Scheme,
+		Existing: []kclient.Object{appInstance, claim, replicaClaim},
+	}
+	resp, err := h.InvokeFunc(t, pv, ReleaseVolume)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Len(t, resp.Client.Updated, 1) {
+		assert.Nil(t, resp.Client.Updated[0].(*corev1.PersistentVolume).Spec.ClaimRef)
+	}
+
+	// The replicas are started when the claim of the first replica exists
+	req = tester.NewRequest(t, scheme.Scheme, appInstance, replicaClaim, pv)
+	sts, err = toStatefulSet(req, appInstance, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "app-target-ns"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &[]int32{2}[0]},
+	}, appInstance.Status.AppSpec.Containers["db"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int32(2), *sts.Spec.Replicas)
+}
@@ -17,8 +17,9 @@ func GCOrphans(req router.Request, resp router.Response) error {
 
 	pod, ok := req.Object.(*corev1.Pod)
 	if ok {
-		// Purge old debug shells
-		if pod.Labels[labels.AcornDebugShell] == "true" && pod.Status.Phase != corev1.PodRunning &&
+		// Purge old debug shells and volume archive pods
+		if (pod.Labels[labels.AcornDebugShell] == "true" || pod.Labels[labels.AcornVolumeHelper] == "true") &&
+			pod.Status.Phase != corev1.PodRunning &&
 			pod.Status.Phase != corev1.PodPending {
 			return req.Client.Delete(req.Ctx, pod)
 		}
@@ -27,6 +27,7 @@ func MarkAndSave(req router.Request, resp router.Response) error {
 		pv.Labels[labels.AcornAppNamespace] != pvc.Labels[labels.AcornAppNamespace] ||
 		pv.Labels[labels.AcornVolumeName] != pvc.Name ||
 		pv.Labels[labels.AcornManaged] != "true" ||
+		pv.Labels[labels.AcornRestoredVolume] != restoredVolume(pv, pvc) ||
 		pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain {
 		if pv.Labels == nil {
 			pv.Labels = map[string]string{}
@@ -36,9 +37,21 @@ func MarkAndSave(req router.Request, resp router.Response) error {
 		pv.Labels[labels.AcornAppName] = pvc.Labels[labels.AcornAppName]
 		pv.Labels[labels.AcornAppNamespace] = pvc.Labels[labels.AcornAppNamespace]
 		pv.Labels[labels.AcornManaged] = "true"
+		if restored := restoredVolume(pv, pvc); restored != "" {
+			pv.Labels[labels.AcornRestoredVolume] = restored
+		}
 		pv.Spec.PersistentVolumeReclaimPolicy = corev1.PersistentVolumeReclaimRetain
 		return req.Client.Update(req.Ctx, &pv)
 	}
 
 	return nil
 }
+
+// restoredVolume is the name a volume restored from a snapshot is bound by. The name is kept when the volume is bound
+// to an app.
+func restoredVolume(pv corev1.PersistentVolume, pvc *corev1.PersistentVolumeClaim) string {
+	if restored := pvc.Labels[labels.AcornRestoredVolume]; restored != "" {
+		return restored
+	}
+	return pv.Labels[labels.AcornRestoredVolume]
+}
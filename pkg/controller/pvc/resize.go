@@ -0,0 +1,75 @@
+package pvc
+
+import (
+	"fmt"
+
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/baaah/pkg/router"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResizeStatus records the progress or failure of expanding a claim on its volume, where it is reported in the
+// status of the Volume resource.
+func ResizeStatus(req router.Request, resp router.Response) error {
+	pvc := req.Object.(*corev1.PersistentVolumeClaim)
+	if pvc.Spec.VolumeName == "" {
+		return nil
+	}
+
+	var pv corev1.PersistentVolume
+	if err := req.Client.Get(req.Ctx, kclient.ObjectKey{Name: pvc.Spec.VolumeName}, &pv); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	status := resizeStatus(pvc)
+	if pv.Annotations[labels.AcornVolumeResizeStatus] == status {
+		return nil
+	}
+
+	if status == "" {
+		delete(pv.Annotations, labels.AcornVolumeResizeStatus)
+	} else {
+		if pv.Annotations == nil {
+			pv.Annotations = map[string]string{}
+		}
+		pv.Annotations[labels.AcornVolumeResizeStatus] = status
+	}
+	return req.Client.Update(req.Ctx, &pv)
+}
+
+func resizeStatus(pvc *corev1.PersistentVolumeClaim) string {
+	if msg := pvc.Annotations[labels.AcornVolumeResizeError]; msg != "" {
+		return "error: " + msg
+	}
+
+	requested := pvc.Spec.Resources.Requests.Storage()
+	if pvc.Status.ResizeStatus != nil {
+		switch *pvc.Status.ResizeStatus {
+		case corev1.PersistentVolumeClaimControllerExpansionFailed, corev1.PersistentVolumeClaimNodeExpansionFailed:
+			return fmt.Sprintf("error: failed to expand volume to %s", requested)
+		}
+	}
+
+	for _, cond := range pvc.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case corev1.PersistentVolumeClaimFileSystemResizePending:
+			return fmt.Sprintf("resizing to %s, waiting for the file system to be resized on the node", requested)
+		case corev1.PersistentVolumeClaimResizing:
+			return fmt.Sprintf("resizing to %s", requested)
+		}
+	}
+
+	capacity := pvc.Status.Capacity.Storage()
+	if pvc.Status.Phase == corev1.ClaimBound && !capacity.IsZero() && requested.Cmp(*capacity) > 0 {
+		return fmt.Sprintf("resizing to %s", requested)
+	}
+
+	return ""
+}
@@ -0,0 +1,37 @@
+package pvc
+
+import (
+	"time"
+
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/baaah/pkg/router"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ReleaseRestored deletes the claim a snapshot was restored to once the volume is provisioned and retained, so the
+// volume can be bound to an app.
+func ReleaseRestored(req router.Request, resp router.Response) error {
+	pvc := req.Object.(*corev1.PersistentVolumeClaim)
+	if pvc.Labels[labels.AcornRestoredVolume] == "" || !pvc.DeletionTimestamp.IsZero() ||
+		pvc.Status.Phase != corev1.ClaimBound || pvc.Spec.VolumeName == "" {
+		return nil
+	}
+
+	var pv corev1.PersistentVolume
+	if err := req.Client.Get(req.Ctx, kclient.ObjectKey{Name: pvc.Spec.VolumeName}, &pv); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// Deleting the claim before the volume is retained would delete the data
+	if pv.Spec.PersistentVolumeReclaimPolicy != corev1.PersistentVolumeReclaimRetain ||
+		pv.Labels[labels.AcornRestoredVolume] != pvc.Labels[labels.AcornRestoredVolume] {
+		resp.RetryAfter(5 * time.Second)
+		return nil
+	}
+
+	return req.Client.Delete(req.Ctx, pvc)
+}
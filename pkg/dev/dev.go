@@ -379,10 +379,11 @@ func appDeleteStop(ctx context.Context, c client.Client, app *apiv1.App, cancel
 }
 
 func appStatusMessage(app *apiv1.App) (string, bool) {
-	return fmt.Sprintf("STATUS: ENDPOINTS[%s] HEALTHY[%s] UPTODATE[%s] %s",
+	return fmt.Sprintf("STATUS: ENDPOINTS[%s] HEALTHY[%s] UPTODATE[%s] REPLICAS[%s] %s",
 		app.Status.Columns.Endpoints,
 		app.Status.Columns.Healthy,
 		app.Status.Columns.UpToDate,
+		app.Status.Columns.Replicas,
 		app.Status.Columns.Message), app.Status.Ready
 }
 
@@ -3,10 +3,12 @@ package encryption
 import (
 	"context"
 	"errors"
+	"sort"
 
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/encryption/nacl"
 	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -27,14 +29,30 @@ func naclKeysToEncryptionKeyList(ctx context.Context, c kclient.Reader, namespac
 	if err != nil {
 		return out, err
 	}
-	for pubKey := range values {
+	for pubKey, key := range values {
 		if pubKey == "primary" {
 			continue
 		}
+		var createdAt *metav1.Time
+		if !key.CreatedAt.IsZero() {
+			createdAt = &metav1.Time{Time: key.CreatedAt}
+		}
 		out = append(out, apiv1.EncryptionKey{
 			KeyID:       pubKey,
 			Annotations: map[string]string{},
+			Primary:     key.Primary != nil && *key.Primary,
+			CreatedAt:   createdAt,
 		})
 	}
+	// The primary key first, then the retired keys from newest to oldest
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Primary != out[j].Primary {
+			return out[i].Primary
+		}
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[j].CreatedAt.Before(out[i].CreatedAt)
+		}
+		return out[i].KeyID < out[j].KeyID
+	})
 	return out, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"sort"
 	"strings"
 
 	"golang.org/x/crypto/nacl/box"
@@ -88,3 +89,23 @@ func unwrapForDecryption(data []byte) (map[string][]byte, error) {
 
 	return returnData, err
 }
+
+// IsEncrypted returns true if the value was encrypted with acorn secret encrypt
+func IsEncrypted(data string) bool {
+	return strings.HasPrefix(data, "ACORNENC:")
+}
+
+// KeyIDs returns the sorted IDs of the public keys an encrypted value can be decrypted with
+func KeyIDs(data string) ([]string, error) {
+	preppedData, err := unwrapForDecryption([]byte(data))
+	if err != nil {
+		return nil, err
+	}
+
+	keyIDs := make([]string, 0, len(preppedData))
+	for keyID := range preppedData {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+	return keyIDs, nil
+}
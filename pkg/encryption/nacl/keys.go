@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/acorn-io/acorn/pkg/labels"
 	"github.com/acorn-io/acorn/pkg/system"
@@ -27,17 +28,19 @@ type NaclKey struct {
 	AcornNamespace    string
 	Primary           *bool
 	PublicKey         *[32]byte
+	CreatedAt         time.Time
 	acornNamespaceUID string
 	privateKey        *[32]byte
 }
 
 type naclKeyStore map[string]naclStoredKey
 type naclStoredKey struct {
-	AcornNamespace    string    `json:"acornNamespace,omitempty"`
-	Primary           *bool     `json:"primary,omitempty"`
-	AcornNamespaceUID string    `json:"acornNamespaceUID,omitempty"`
-	PrivateKey        *[32]byte `json:"privateKey,omitempty"`
-	PublicKey         *[32]byte `json:"publicKey,omitempty"`
+	AcornNamespace    string     `json:"acornNamespace,omitempty"`
+	Primary           *bool      `json:"primary,omitempty"`
+	AcornNamespaceUID string     `json:"acornNamespaceUID,omitempty"`
+	PrivateKey        *[32]byte  `json:"privateKey,omitempty"`
+	PublicKey         *[32]byte  `json:"publicKey,omitempty"`
+	CreatedAt         *time.Time `json:"createdAt,omitempty"`
 }
 
 func GetOrCreatePrimaryNaclKey(ctx context.Context, c kclient.Client, namespace string) (*NaclKey, error) {
@@ -99,9 +102,58 @@ func GetPublicKey(ctx context.Context, c kclient.Reader, namespace string) (stri
 	return "", err
 }
 
+// RotateNaclKey generates a new primary key for the namespace. The previous keys are kept, so values encrypted with
+// them can still be decrypted.
+func RotateNaclKey(ctx context.Context, c kclient.Client, namespace string) (*NaclKey, error) {
+	existing, err := getExistingSecret(ctx, c, namespace)
+	if apierrors.IsNotFound(err) {
+		return generateNewKeys(ctx, c, namespace, nil)
+	} else if err != nil {
+		return nil, err
+	}
+	return generateNewKeys(ctx, c, namespace, existing)
+}
+
+// DeleteNaclKeys deletes retired keys of the namespace. Values that were only encrypted with them can no longer be
+// decrypted. The primary key can't be deleted.
+func DeleteNaclKeys(ctx context.Context, c kclient.Client, namespace string, publicKeys ...string) error {
+	existing, err := getExistingSecret(ctx, c, namespace)
+	if apierrors.IsNotFound(err) {
+		return &ErrKeyNotFound{}
+	} else if err != nil {
+		return err
+	}
+
+	store := naclKeyStore{}
+	if err := json.Unmarshal(existing.Data[naclStoreKey], &store); err != nil {
+		return err
+	}
+
+	for _, publicKey := range publicKeys {
+		key, ok := store[publicKey]
+		if !ok {
+			return fmt.Errorf("key %s not found", publicKey)
+		}
+		if key.Primary != nil && *key.Primary {
+			return fmt.Errorf("key %s is the primary key and can not be deleted", publicKey)
+		}
+		delete(store, publicKey)
+	}
+
+	updatedSecret := existing.DeepCopy()
+	updatedSecret.Data[naclStoreKey], err = json.Marshal(store)
+	if err != nil {
+		return err
+	}
+	return c.Update(ctx, updatedSecret)
+}
+
+// generateNewKeys generates a new primary key. Existing keys stay available for decryption.
 func generateNewKeys(ctx context.Context, c kclient.Client, namespace string, existing *corev1.Secret) (*NaclKey, error) {
 	naclKey := &NaclKey{
 		AcornNamespace: namespace,
+		Primary:        &[]bool{true}[0],
+		CreatedAt:      time.Now().UTC().Truncate(time.Second),
 	}
 	publicKey, privateKey, err := box.GenerateKey(crypto_rand.Reader)
 	if err != nil {
@@ -121,9 +173,6 @@ func generateNewKeys(ctx context.Context, c kclient.Client, namespace string, ex
 	}
 
 	naclKey.acornNamespaceUID = string(ns.UID)
-	if existing == nil {
-		naclKey.Primary = &[]bool{true}[0]
-	}
 
 	return naclKey, createOrUpdateNaclKeySecret(ctx, c, naclKey, existing)
 }
@@ -214,14 +263,20 @@ func secretToNaclKeys(secret *corev1.Secret, namespace string) (NaclKeys, error)
 		if err != nil {
 			return nil, err
 		}
+		// Keys created before creation times were recorded are as old as the secret
+		createdAt := secret.CreationTimestamp.Time
+		if keyInfo.CreatedAt != nil {
+			createdAt = *keyInfo.CreatedAt
+		}
 		to[pubKeyString] = &NaclKey{
 			AcornNamespace:    keyInfo.AcornNamespace,
 			Primary:           keyInfo.Primary,
 			PublicKey:         pubKey,
+			CreatedAt:         createdAt,
 			privateKey:        keyInfo.PrivateKey,
 			acornNamespaceUID: string(uid),
 		}
-		if *keyInfo.Primary {
+		if keyInfo.Primary != nil && *keyInfo.Primary {
 			to["primary"] = to[pubKeyString]
 		}
 	}
@@ -246,12 +301,24 @@ func (k *NaclKey) toSecretData(existingData map[string][]byte) (map[string][]byt
 	}
 	stringKey := keyBytesToB64String(k.PublicKey)
 	logrus.Errorf("StringKey: %s", stringKey)
+	if k.Primary != nil && *k.Primary {
+		// There is only one primary key, the others are only used for decryption
+		for pubKey, key := range store {
+			key.Primary = &[]bool{false}[0]
+			store[pubKey] = key
+		}
+	}
+	var createdAt *time.Time
+	if !k.CreatedAt.IsZero() {
+		createdAt = &k.CreatedAt
+	}
 	store[stringKey] = naclStoredKey{
 		AcornNamespace:    k.AcornNamespace,
 		Primary:           k.Primary,
 		AcornNamespaceUID: k.acornNamespaceUID,
 		PrivateKey:        k.privateKey,
 		PublicKey:         k.PublicKey,
+		CreatedAt:         createdAt,
 	}
 
 	to[naclStoreKey], err = json.Marshal(store)
@@ -282,3 +349,8 @@ func secretNameGen(namespace, uid string) string {
 	}
 	return fmt.Sprintf("%s-%s-enc-keys", namespace, uid)
 }
+
+// PublicKeyID returns the ID of the key that values are encrypted for
+func (k *NaclKey) PublicKeyID() string {
+	return keyBytesToB64String(k.PublicKey)
+}
@@ -0,0 +1,62 @@
+package nacl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestRotateNaclKey(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "acorn",
+			UID:  "0123456789abcdef",
+		},
+	}).Build()
+
+	first, err := GetOrCreatePrimaryNaclKey(ctx, c, "acorn")
+	require.NoError(t, err)
+
+	encrypted, err := MultipleKeyEncrypt("secret", []string{first.PublicKeyID()})
+	require.NoError(t, err)
+	value, err := encrypted.Marshal()
+	require.NoError(t, err)
+
+	second, err := RotateNaclKey(ctx, c, "acorn")
+	require.NoError(t, err)
+	assert.NotEqual(t, first.PublicKeyID(), second.PublicKeyID())
+
+	keys, err := GetAllNaclKeys(ctx, c, "acorn")
+	require.NoError(t, err)
+	assert.Len(t, keys, 3)
+	assert.Equal(t, second.PublicKeyID(), keys["primary"].PublicKeyID())
+	assert.False(t, *keys[first.PublicKeyID()].Primary)
+	assert.False(t, keys[first.PublicKeyID()].CreatedAt.IsZero())
+
+	// Values encrypted with the retired key can still be decrypted
+	data, err := DecryptNamespacedData(ctx, c, []byte(value), "acorn")
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(data))
+
+	keyIDs, err := KeyIDs(value)
+	require.NoError(t, err)
+	assert.Equal(t, []string{first.PublicKeyID()}, keyIDs)
+
+	assert.ErrorContains(t, DeleteNaclKeys(ctx, c, "acorn", second.PublicKeyID()), "is the primary key")
+	assert.ErrorContains(t, DeleteNaclKeys(ctx, c, "acorn", "missing"), "key missing not found")
+
+	require.NoError(t, DeleteNaclKeys(ctx, c, "acorn", first.PublicKeyID()))
+	_, err = DecryptNamespacedData(ctx, c, []byte(value), "acorn")
+	assert.Error(t, err)
+
+	publicKey, err := GetPublicKey(ctx, c, "acorn")
+	require.NoError(t, err)
+	assert.Equal(t, second.PublicKeyID(), publicKey)
+}
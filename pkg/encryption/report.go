@@ -0,0 +1,192 @@
+package encryption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/encryption/nacl"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RetiredKeyReference is an encrypted value in an app that can't be decrypted with the primary key
+type RetiredKeyReference struct {
+	App    string   `json:"app"`
+	Field  string   `json:"field"`
+	KeyIDs []string `json:"keyIDs"`
+}
+
+// RetiredKeyReferences returns the encrypted values in the spec and parsed Acornfile of the apps that can only be
+// decrypted with retired keys. They have to be encrypted again with the primary key before the retired keys are
+// deleted.
+func RetiredKeyReferences(apps []apiv1.App, keys []apiv1.EncryptionKey) ([]RetiredKeyReference, error) {
+	var primary string
+	for _, key := range keys {
+		if key.Primary {
+			primary = key.KeyID
+		}
+	}
+
+	var result []RetiredKeyReference
+	for _, app := range apps {
+		for _, field := range []struct {
+			prefix string
+			obj    any
+		}{
+			{prefix: "spec", obj: app.Spec},
+			{prefix: "status.appSpec", obj: app.Status.AppSpec},
+		} {
+			data, err := json.Marshal(field.obj)
+			if err != nil {
+				return nil, err
+			}
+			var obj any
+			if err := json.Unmarshal(data, &obj); err != nil {
+				return nil, err
+			}
+
+			err = walkStrings(field.prefix, obj, func(field, value string) error {
+				if !nacl.IsEncrypted(value) {
+					return nil
+				}
+				keyIDs, err := nacl.KeyIDs(value)
+				if err != nil {
+					return err
+				}
+				for _, keyID := range keyIDs {
+					if keyID == primary {
+						return nil
+					}
+				}
+				result = append(result, RetiredKeyReference{
+					App:    app.Name,
+					Field:  field,
+					KeyIDs: keyIDs,
+				})
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].App != result[j].App {
+			return result[i].App < result[j].App
+		}
+		return result[i].Field < result[j].Field
+	})
+	return result, nil
+}
+
+// DeletedKeyReferences returns the encrypted values in the apps that can no longer be decrypted once the keys with the
+// given IDs are deleted
+func DeletedKeyReferences(apps []apiv1.App, keys []apiv1.EncryptionKey, keyIDs []string) ([]RetiredKeyReference, error) {
+	refs, err := RetiredKeyReferences(apps, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := map[string]bool{}
+	for _, keyID := range keyIDs {
+		deleted[keyID] = true
+	}
+	remaining := map[string]bool{}
+	for _, key := range keys {
+		if !deleted[key.KeyID] {
+			remaining[key.KeyID] = true
+		}
+	}
+
+	var result []RetiredKeyReference
+	for _, ref := range refs {
+		var usesDeleted, usesRemaining bool
+		for _, keyID := range ref.KeyIDs {
+			usesDeleted = usesDeleted || deleted[keyID]
+			usesRemaining = usesRemaining || remaining[keyID]
+		}
+		// Values that can't be decrypted with any key already are not affected by the deletion
+		if usesDeleted && !usesRemaining {
+			result = append(result, ref)
+		}
+	}
+	return result, nil
+}
+
+// ErrKeysInUse is returned when keys are deleted that values in apps can only be decrypted with
+type ErrKeysInUse struct {
+	References []RetiredKeyReference
+}
+
+func (e *ErrKeysInUse) Error() string {
+	var values []string
+	for _, ref := range e.References {
+		values = append(values, fmt.Sprintf("%s %s (%s)", ref.App, ref.Field, strings.Join(ref.KeyIDs, ", ")))
+	}
+	return fmt.Sprintf("values in apps can only be decrypted with the keys, encrypt them again with the primary key "+
+		"or force the deletion: %s", strings.Join(values, "; "))
+}
+
+// DeleteKeys deletes retired keys of the namespace. Unless force is set, the keys are not deleted if values in the
+// apps of the namespace can only be decrypted with them.
+func DeleteKeys(ctx context.Context, c kclient.Client, namespace string, force bool, keyIDs ...string) error {
+	if !force {
+		appInstances := &v1.AppInstanceList{}
+		if err := c.List(ctx, appInstances, kclient.InNamespace(namespace)); err != nil {
+			return err
+		}
+		apps := make([]apiv1.App, 0, len(appInstances.Items))
+		for _, app := range appInstances.Items {
+			apps = append(apps, apiv1.App{
+				ObjectMeta: app.ObjectMeta,
+				Spec:       app.Spec,
+				Status:     app.Status,
+			})
+		}
+
+		keys, err := GetEncryptionKeyList(ctx, c, namespace)
+		if err != nil {
+			return err
+		}
+
+		refs, err := DeletedKeyReferences(apps, keys, keyIDs)
+		if err != nil {
+			return err
+		}
+		if len(refs) > 0 {
+			return &ErrKeysInUse{References: refs}
+		}
+	}
+
+	return nacl.DeleteNaclKeys(ctx, c, namespace, keyIDs...)
+}
+
+func walkStrings(path string, obj any, f func(field, value string) error) error {
+	switch v := obj.(type) {
+	case string:
+		return f(path, v)
+	case map[string]any:
+		for key, value := range v {
+			field := path + "." + key
+			if strings.ContainsAny(key, ".[]") {
+				field = path + "[" + strconv.Quote(key) + "]"
+			}
+			if err := walkStrings(field, value, f); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, value := range v {
+			if err := walkStrings(path+"["+strconv.Itoa(i)+"]", value, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package encryption
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/encryption/nacl"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func encrypt(t *testing.T, keys ...string) string {
+	t.Helper()
+
+	data, err := nacl.MultipleKeyEncrypt("secret", keys)
+	require.NoError(t, err)
+	value, err := data.Marshal()
+	require.NoError(t, err)
+	return value
+}
+
+func TestRetiredKeyReferences(t *testing.T) {
+	// Public keys are base64 encoded 32 byte values
+	primary := "cHJpbWFyeS1rZXktcHJpbWFyeS1rZXktcHJpbWFyeS0"
+	retired := "cmV0aXJlZC1rZXktcmV0aXJlZC1rZXktcmV0aXJlZC0"
+
+	apps := []apiv1.App{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "app"},
+			Spec: v1.AppInstanceSpec{
+				DeployArgs: v1.GenericMap{
+					"password": encrypt(t, retired),
+					"both":     encrypt(t, retired, primary),
+					"plain":    "value",
+				},
+			},
+			Status: v1.AppInstanceStatus{
+				AppSpec: v1.AppSpec{
+					Secrets: map[string]v1.Secret{
+						"db": {
+							Type: "opaque",
+							Data: map[string]string{
+								"password": encrypt(t, retired),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			Spec: v1.AppInstanceSpec{
+				DeployArgs: v1.GenericMap{
+					"password": encrypt(t, primary),
+				},
+			},
+		},
+	}
+
+	refs, err := RetiredKeyReferences(apps, []apiv1.EncryptionKey{
+		{KeyID: primary, Primary: true},
+		{KeyID: retired},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []RetiredKeyReference{
+		{App: "app", Field: "spec.deployArgs.password", KeyIDs: []string{retired}},
+		{App: "app", Field: "status.appSpec.secrets.db.data.password", KeyIDs: []string{retired}},
+	}, refs)
+}
+
+func TestDeletedKeyReferences(t *testing.T) {
+	primary := "cHJpbWFyeS1rZXktcHJpbWFyeS1rZXktcHJpbWFyeS0"
+	retired := "cmV0aXJlZC1rZXktcmV0aXJlZC1rZXktcmV0aXJlZC0"
+	older := "b2xkZXIta2V5LW9sZGVyLWtleS1vbGRlci1rZXktb2w"
+	deleted := "ZGVsZXRlZC1rZXktZGVsZXRlZC1rZXktZGVsZXRlZC0"
+
+	apps := []apiv1.App{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "app"},
+			Spec: v1.AppInstanceSpec{
+				DeployArgs: v1.GenericMap{
+					"retired": encrypt(t, retired),
+					"older":   encrypt(t, older),
+					"both":    encrypt(t, retired, older),
+					"primary": encrypt(t, retired, primary),
+					"deleted": encrypt(t, deleted),
+				},
+			},
+		},
+	}
+	keys := []apiv1.EncryptionKey{
+		{KeyID: primary, Primary: true},
+		{KeyID: retired},
+		{KeyID: older},
+	}
+
+	refs, err := DeletedKeyReferences(apps, keys, []string{retired})
+	require.NoError(t, err)
+	assert.Equal(t, []RetiredKeyReference{
+		{App: "app", Field: "spec.deployArgs.retired", KeyIDs: []string{retired}},
+	}, refs)
+
+	refs, err = DeletedKeyReferences(apps, keys, []string{retired, older})
+	require.NoError(t, err)
+	assert.Len(t, refs, 3)
+}
+
+func TestDeleteKeys(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "acorn",
+			UID:  "0123456789abcdef",
+		},
+	}).Build()
+
+	retired, err := nacl.GetOrCreatePrimaryNaclKey(ctx, c, "acorn")
+	require.NoError(t, err)
+	_, err = nacl.RotateNaclKey(ctx, c, "acorn")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Create(ctx, &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "acorn",
+		},
+		Spec: v1.AppInstanceSpec{
+			DeployArgs: v1.GenericMap{
+				"password": encrypt(t, retired.PublicKeyID()),
+			},
+		},
+	}))
+
+	var inUse *ErrKeysInUse
+	err = DeleteKeys(ctx, c, "acorn", false, retired.PublicKeyID())
+	require.ErrorAs(t, err, &inUse)
+	assert.Equal(t, []RetiredKeyReference{
+		{App: "app", Field: "spec.deployArgs.password", KeyIDs: []string{retired.PublicKeyID()}},
+	}, inUse.References)
+	assert.ErrorContains(t, err, "app spec.deployArgs.password ("+retired.PublicKeyID()+")")
+
+	keys, err := GetEncryptionKeyList(ctx, c, "acorn")
+	require.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	require.NoError(t, DeleteKeys(ctx, c, "acorn", true, retired.PublicKeyID()))
+	keys, err = GetEncryptionKeyList(ctx, c, "acorn")
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
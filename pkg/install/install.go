@@ -57,8 +57,10 @@ type Options struct {
 	OutputFormat       string
 	APIServerReplicas  *int
 	ControllerReplicas *int
-	Config             apiv1.Config
-	Progress           progress.Builder
+	// RegistryWebhookPort is the port the api-server also serves the registry webhook on over plain HTTP, 0 to disable
+	RegistryWebhookPort *int
+	Config              apiv1.Config
+	Progress            progress.Builder
 }
 
 func (o *Options) complete() *Options {
@@ -79,6 +81,10 @@ func (o *Options) complete() *Options {
 		o.ControllerReplicas = &[]int{1}[0]
 	}
 
+	if o.RegistryWebhookPort == nil {
+		o.RegistryWebhookPort = new(int)
+	}
+
 	return o
 }
 
@@ -98,6 +104,11 @@ func Install(ctx context.Context, image string, opts *Options) error {
 			return err
 		}
 	}
+	if opts.Config.AutoUpgradeMaintenanceWindow != nil && *opts.Config.AutoUpgradeMaintenanceWindow != "" {
+		if _, err := validate.MaintenanceWindow(*opts.Config.AutoUpgradeMaintenanceWindow); err != nil {
+			return err
+		}
+	}
 
 	c, err := k8sclient.Default()
 	if err != nil {
@@ -210,7 +221,7 @@ func Install(ctx context.Context, image string, opts *Options) error {
 	s.Success()
 
 	s = opts.Progress.New(fmt.Sprintf("Installing APIServer and Controller (image %s)", image))
-	if err := applyDeployments(ctx, image, *opts.APIServerReplicas, *opts.ControllerReplicas, apply, c); err != nil {
+	if err := applyDeployments(ctx, image, *opts.APIServerReplicas, *opts.ControllerReplicas, *opts.RegistryWebhookPort, apply, c); err != nil {
 		return s.Fail(err)
 	}
 	s.Success()
@@ -389,7 +400,7 @@ func resources(image string, opts *Options) ([]kclient.Object, error) {
 	}
 	objs = append(objs, namespace...)
 
-	deps, err := Deployments(image, *opts.APIServerReplicas, *opts.ControllerReplicas)
+	deps, err := Deployments(image, *opts.APIServerReplicas, *opts.ControllerReplicas, *opts.RegistryWebhookPort)
 	if err != nil {
 		return nil, err
 	}
@@ -430,7 +441,7 @@ func printObject(image string, opts *Options) error {
 	return err
 }
 
-func applyDeployments(ctx context.Context, imageName string, apiServerReplicas, controllerReplicas int, apply apply.Apply, c kclient.Client) error {
+func applyDeployments(ctx context.Context, imageName string, apiServerReplicas, controllerReplicas, registryWebhookPort int, apply apply.Apply, c kclient.Client) error {
 	// handle upgrade from <= v0.3.x
 	if err := resetNamespace(ctx, c); err != nil {
 		return err
@@ -441,7 +452,7 @@ func applyDeployments(ctx context.Context, imageName string, apiServerReplicas,
 		return err
 	}
 
-	deps, err := Deployments(imageName, apiServerReplicas, controllerReplicas)
+	deps, err := Deployments(imageName, apiServerReplicas, controllerReplicas, registryWebhookPort)
 	if err != nil {
 		return err
 	}
@@ -481,7 +492,7 @@ func Namespace() ([]kclient.Object, error) {
 	return objectsFromFile("namespace.yaml")
 }
 
-func Deployments(runtimeImage string, apiServerReplicas, controllerReplicas int) ([]kclient.Object, error) {
+func Deployments(runtimeImage string, apiServerReplicas, controllerReplicas, registryWebhookPort int) ([]kclient.Object, error) {
 	apiServerObjects, err := objectsFromFile("apiserver.yaml")
 	if err != nil {
 		return nil, err
@@ -502,9 +513,50 @@ func Deployments(runtimeImage string, apiServerReplicas, controllerReplicas int)
 		return nil, err
 	}
 
+	if registryWebhookPort != 0 {
+		apiServerObjects, err = addRegistryWebhookPort(registryWebhookPort, apiServerObjects)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return replaceImage(runtimeImage, append(apiServerObjects, controllerObjects...))
 }
 
+// addRegistryWebhookPort has the api-server also serve the registry webhook over plain HTTP on the port, and exposes
+// the port on the acorn-api service
+func addRegistryWebhookPort(port int, objs []kclient.Object) ([]kclient.Object, error) {
+	for _, obj := range objs {
+		ustr := obj.(*unstructured.Unstructured)
+		switch ustr.GetKind() {
+		case "Deployment":
+			containers, _, _ := unstructured.NestedSlice(ustr.Object, "spec", "template", "spec", "containers")
+			for _, container := range containers {
+				container := container.(map[string]any)
+				container["args"] = append(container["args"].([]any), fmt.Sprintf("--registry-webhook-port=%d", port))
+				container["ports"] = append(container["ports"].([]any), map[string]any{
+					"containerPort": int64(port),
+				})
+			}
+			if err := unstructured.SetNestedSlice(ustr.Object, containers, "spec", "template", "spec", "containers"); err != nil {
+				return nil, err
+			}
+		case "Service":
+			ports, _, _ := unstructured.NestedSlice(ustr.Object, "spec", "ports")
+			ports = append(ports, map[string]any{
+				"name":       "registry-webhook",
+				"port":       int64(port),
+				"targetPort": int64(port),
+				"protocol":   "TCP",
+			})
+			if err := unstructured.SetNestedSlice(ustr.Object, ports, "spec", "ports"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return objs, nil
+}
+
 func replaceReplicas(replicas int, objs []kclient.Object) ([]kclient.Object, error) {
 	for _, obj := range objs {
 		ustr := obj.(*unstructured.Unstructured)
@@ -11,6 +11,7 @@ import (
 const (
 	Prefix                       = "acorn.io/"
 	AcornAppGeneration           = Prefix + "app-generation"
+	AcornAppImageDigest          = Prefix + "app-image-digest"
 	AcornAppNamespace            = Prefix + "app-namespace"
 	AcornAppName                 = Prefix + "app-name"
 	AcornAcornName               = Prefix + "acorn-name"
@@ -45,6 +46,18 @@ const (
 	AcornCertNotValidBefore      = Prefix + "cert-not-valid-before"
 	AcornCertNotValidAfter       = Prefix + "cert-not-valid-after"
 	AcornLetsEncryptSettingsHash = Prefix + "le-hash"
+	AcornAutoUpgradeCheck        = Prefix + "auto-upgrade-check"
+	AcornCertificateAuthority    = Prefix + "certificate-authority"
+	AcornSecretRotate            = Prefix + "secret-rotate"
+	AcornSecretRotatedAt         = Prefix + "secret-rotated-at"
+	AcornSecretPreviousExpires   = Prefix + "secret-previous-expires"
+	AcornSecretRefreshedAt       = Prefix + "secret-refreshed-at"
+	AcornSnapshotVolume          = Prefix + "snapshot-volume"
+	AcornRestoredVolume          = Prefix + "restored-volume"
+	AcornVolumeHelper            = Prefix + "volume-helper"
+	AcornVolumeSeeded            = Prefix + "volume-seeded"
+	AcornVolumeResizeError       = Prefix + "volume-resize-error"
+	AcornVolumeResizeStatus      = Prefix + "volume-resize-status"
 )
 
 func Merge(base, overlay map[string]string) map[string]string {
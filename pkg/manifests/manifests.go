@@ -0,0 +1,134 @@
+package manifests
+
+import (
+	"context"
+	"io"
+
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/appdefinition"
+	controller "github.com/acorn-io/acorn/pkg/controller/appdefinition"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/acorn/pkg/system"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+type Options struct {
+	// Name of the app, defaults to "app"
+	Name string
+	// Namespace the app would be created in, defaults to the acorn user namespace
+	Namespace string
+	// TargetNamespace the app's objects are created in, defaults to the app name
+	TargetNamespace string
+	// Image is the reference of the app image, defaults to the app name
+	Image string
+	// Profiles that were applied when the app definition was evaluated
+	Profiles []string
+}
+
+func (o Options) complete() Options {
+	if o.Name == "" {
+		o.Name = "app"
+	}
+	if o.Namespace == "" {
+		o.Namespace = system.DefaultUserNamespace
+	}
+	if o.TargetNamespace == "" {
+		o.TargetNamespace = o.Name
+	}
+	if o.Image == "" {
+		o.Image = o.Name
+	}
+	return o
+}
+
+// FromAppDefinition returns the Kubernetes objects that acorn would create for an app running the
+// given definition. No cluster is needed.
+func FromAppDefinition(ctx context.Context, appDef *appdefinition.AppDefinition, opts Options) ([]kclient.Object, error) {
+	appSpec, err := appDef.AppSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	appInstance := ToAppInstance(appSpec, opts)
+	objs, err := controller.Manifests(ctx, appInstance)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+		if err != nil {
+			return nil, err
+		}
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+
+	return objs, nil
+}
+
+// ToAppInstance returns the AppInstance the controller would see after the app was created and its
+// image was pulled.
+func ToAppInstance(appSpec *v1.AppSpec, opts Options) *v1.AppInstance {
+	opts = opts.complete()
+	appSpec = appSpec.DeepCopy()
+
+	// Images that are built from source do not have a reference until they are built,
+	// so the container name stands in for it.
+	for name, container := range appSpec.Containers {
+		appSpec.Containers[name] = withImagePlaceholder(name, container)
+	}
+	for name, job := range appSpec.Jobs {
+		appSpec.Jobs[name] = withImagePlaceholder(name, job)
+	}
+
+	return &v1.AppInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opts.Name,
+			Namespace: opts.Namespace,
+		},
+		Spec: v1.AppInstanceSpec{
+			Image:    opts.Image,
+			Profiles: opts.Profiles,
+		},
+		Status: v1.AppInstanceStatus{
+			Namespace: opts.TargetNamespace,
+			AppImage: v1.AppImage{
+				ID: opts.Image,
+			},
+			AppSpec: *appSpec,
+		},
+	}
+}
+
+func withImagePlaceholder(name string, container v1.Container) v1.Container {
+	if container.Image == "" {
+		container.Image = name
+	}
+	for sidecarName, sidecar := range container.Sidecars {
+		if sidecar.Image == "" {
+			sidecar.Image = sidecarName
+		}
+		container.Sidecars[sidecarName] = sidecar
+	}
+	return container
+}
+
+// Write writes the objects as a multi document YAML stream
+func Write(w io.Writer, objs []kclient.Object) error {
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte("---\n")); err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
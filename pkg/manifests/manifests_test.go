@@ -0,0 +1,75 @@
+package manifests
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/acorn-io/acorn/pkg/appdefinition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestFromAppDefinition(t *testing.T) {
+	appDef, err := appdefinition.NewAppDefinition([]byte(`
+containers: {
+	web: {
+		build: "."
+		ports: publish: "80/http"
+		sidecars: setup: {
+			image: "busybox"
+			init:  true
+		}
+	}
+}
+jobs: migrate: image: "migrate"
+volumes: data: {}
+`))
+	require.NoError(t, err)
+
+	objs, err := FromAppDefinition(context.Background(), appDef, Options{
+		Name: "test",
+	})
+	require.NoError(t, err)
+
+	var (
+		dep    *appsv1.Deployment
+		job    *batchv1.Job
+		pvc    *corev1.PersistentVolumeClaim
+		hasNS  bool
+		hasSvc bool
+	)
+	for _, obj := range objs {
+		assert.NotEmpty(t, obj.GetObjectKind().GroupVersionKind().Kind)
+		switch o := obj.(type) {
+		case *appsv1.Deployment:
+			dep = o
+		case *batchv1.Job:
+			job = o
+		case *corev1.PersistentVolumeClaim:
+			pvc = o
+		case *corev1.Namespace:
+			hasNS = o.Name == "test"
+		case *corev1.Service:
+			hasSvc = hasSvc || o.Name == "web"
+		}
+	}
+
+	require.NotNil(t, dep)
+	assert.Equal(t, "test", dep.Namespace)
+	assert.Equal(t, "web", dep.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, "busybox", dep.Spec.Template.Spec.InitContainers[0].Image)
+	require.NotNil(t, job)
+	assert.Equal(t, "migrate", job.Spec.Template.Spec.Containers[0].Image)
+	require.NotNil(t, pvc)
+	assert.Equal(t, "data", pvc.Name)
+	assert.True(t, hasNS)
+	assert.True(t, hasSvc)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, Write(buf, objs))
+	assert.Equal(t, len(objs), bytes.Count(buf.Bytes(), []byte("---\n")))
+}
@@ -58,7 +58,7 @@ func Router(req router.Request, app *v1.AppInstance) (result []kclient.Object, _
 			if ok {
 				for _, hostname := range hostnames {
 					targets[hostname] = Target{Port: port.TargetPort, Service: serviceName}
-					rules = append(rules, routerRule(hostname, router))
+					rules = append(rules, routerRule(hostname, serviceName, router))
 				}
 			}
 			svcName := serviceName
@@ -72,7 +72,7 @@ func Router(req router.Request, app *v1.AppInstance) (result []kclient.Object, _
 				}
 				hostnameMinusPort, _, _ := strings.Cut(hostname, ":")
 				targets[hostname] = Target{Port: port.TargetPort, Service: serviceName}
-				rules = append(rules, routerRule(hostnameMinusPort, router))
+				rules = append(rules, routerRule(hostnameMinusPort, serviceName, router))
 			}
 		}
 
@@ -127,7 +127,9 @@ func Router(req router.Request, app *v1.AppInstance) (result []kclient.Object, _
 	return result, nil
 }
 
-func routerRule(host string, router v1.Router) networkingv1.IngressRule {
+// routerRule sends each route straight to its target service. Routes that split traffic between targets, redirect, or
+// rewrite the request are sent to the router itself, so its nginx can handle them.
+func routerRule(host, routerName string, router v1.Router) networkingv1.IngressRule {
 	rule := networkingv1.IngressRule{
 		Host: host,
 		IngressRuleValue: networkingv1.IngressRuleValue{
@@ -135,14 +137,17 @@ func routerRule(host string, router v1.Router) networkingv1.IngressRule {
 		},
 	}
 	for _, route := range router.Routes {
-		if route.Path == "" || route.TargetServiceName == "" {
+		serviceName, port := route.TargetServiceName, route.TargetPort
+		if handledByRouter(route) {
+			serviceName, port = routerName, int(ports.RouterPortDef.Port)
+		}
+		if route.Path == "" || serviceName == "" {
 			continue
 		}
 		pathType := networkingv1.PathTypePrefix
 		if route.PathType == v1.PathTypeExact {
 			pathType = networkingv1.PathTypeExact
 		}
-		port := route.TargetPort
 		if port == 0 {
 			port = 80
 		}
@@ -151,7 +156,7 @@ func routerRule(host string, router v1.Router) networkingv1.IngressRule {
 			PathType: &pathType,
 			Backend: networkingv1.IngressBackend{
 				Service: &networkingv1.IngressServiceBackend{
-					Name: route.TargetServiceName,
+					Name: serviceName,
 					Port: networkingv1.ServiceBackendPort{
 						Number: int32(port),
 					},
@@ -161,3 +166,8 @@ func routerRule(host string, router v1.Router) networkingv1.IngressRule {
 	}
 	return rule
 }
+
+func handledByRouter(route v1.Route) bool {
+	return len(route.Targets) > 0 || route.Redirect != nil || route.Rewrite != "" ||
+		len(route.SetRequestHeaders) > 0 || len(route.RemoveResponseHeaders) > 0
+}
@@ -23,6 +23,7 @@ var (
 					"builders",
 					"images",
 					"volumes",
+					"volumesnapshots",
 					"containerreplicas",
 					"credentials",
 					"secrets",
@@ -32,6 +33,7 @@ var (
 				Verbs: []string{"get"},
 				Resources: []string{
 					"apps/log",
+					"apps/revisions",
 					"images/details",
 				},
 			},
@@ -57,6 +59,8 @@ var (
 				Resources: []string{
 					"images/tag",
 					"apps/confirmupgrade",
+					"apps/diff",
+					"volumesnapshots/restore",
 				},
 			},
 			{
@@ -66,6 +70,12 @@ var (
 					"containerreplicas",
 				},
 			},
+			{
+				Verbs: []string{"create", "delete"},
+				Resources: []string{
+					"volumesnapshots",
+				},
+			},
 			{
 				Verbs: []string{"get"},
 				Resources: []string{
@@ -73,6 +83,17 @@ var (
 					"images/pull",
 					"containerreplicas/exec",
 					"secrets/expose",
+					"volumes/export",
+					"volumes/import",
+				},
+			},
+		},
+		Admin: {
+			{
+				Verbs: []string{"create"},
+				Resources: []string{
+					"encryptionkeyrotations",
+					"encryptionkeydeletions",
 				},
 			},
 		},
@@ -100,7 +121,7 @@ func ClusterRoles() []rbacv1.ClusterRole {
 			ObjectMeta: metav1.ObjectMeta{
 				Name: Admin,
 			},
-			Rules: append(projectRoles[View], append(projectRoles[Edit], projectRoles[Build]...)...),
+			Rules: append(projectRoles[View], append(projectRoles[Edit], append(projectRoles[Build], projectRoles[Admin]...)...)...),
 		},
 		{
 			ObjectMeta: metav1.ObjectMeta{
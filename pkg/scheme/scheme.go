@@ -3,13 +3,16 @@ package scheme
 import (
 	acornapiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
 	acornv1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
 	"github.com/rancher/wrangler/pkg/merr"
 	"github.com/rancher/wrangler/pkg/schemes"
 	appsv1 "k8s.io/api/apps/v1"
 	authv1 "k8s.io/api/authorization/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -32,12 +35,15 @@ func AddToScheme(scheme *runtime.Scheme) error {
 	errs = append(errs, corev1.AddToScheme(scheme))
 	errs = append(errs, appsv1.AddToScheme(scheme))
 	errs = append(errs, batchv1.AddToScheme(scheme))
+	errs = append(errs, autoscalingv2.AddToScheme(scheme))
 	errs = append(errs, networkingv1.AddToScheme(scheme))
+	errs = append(errs, policyv1.AddToScheme(scheme))
 	errs = append(errs, storagev1.AddToScheme(scheme))
 	errs = append(errs, apiregistrationv1.AddToScheme(scheme))
 	errs = append(errs, rbacv1.AddToScheme(scheme))
 	errs = append(errs, authv1.AddToScheme(scheme))
 	errs = append(errs, apiextensionv1.AddToScheme(scheme))
+	errs = append(errs, snapshotv1.AddToScheme(scheme))
 	return merr.NewErrors(errs...)
 }
 
@@ -0,0 +1,93 @@
+package apps
+
+import (
+	"context"
+	"net/http"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/appdefinition"
+	"github.com/acorn-io/acorn/pkg/appdiff"
+	"github.com/acorn-io/acorn/pkg/autoupgrade"
+	"github.com/acorn-io/acorn/pkg/images"
+	kclient "github.com/acorn-io/acorn/pkg/k8sclient"
+	"github.com/acorn-io/acorn/pkg/tags"
+	"github.com/acorn-io/mink/pkg/stores"
+	"github.com/acorn-io/mink/pkg/types"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewDiff(c client.WithWatch, transport http.RoundTripper) rest.Storage {
+	return stores.NewBuilder(c.Scheme(), &apiv1.AppDiff{}).
+		WithCreate(&DiffStrategy{
+			client:    c,
+			remoteOpt: remote.WithTransport(transport),
+		}).
+		Build()
+}
+
+// DiffStrategy is a dry run of an app update. The AppSpec of the updated app is rendered from the candidate image
+// and args and compared to the AppSpec that is currently deployed, nothing is persisted.
+type DiffStrategy struct {
+	client    client.WithWatch
+	remoteOpt remote.Option
+}
+
+func (s *DiffStrategy) Create(ctx context.Context, obj types.Object) (types.Object, error) {
+	diff := obj.(*apiv1.AppDiff)
+	ri, _ := request.RequestInfoFrom(ctx)
+
+	app := &v1.AppInstance{}
+	err := s.client.Get(ctx, kclient.ObjectKey{Namespace: ri.Namespace, Name: ri.Name}, app)
+	if err != nil {
+		return nil, err
+	}
+
+	image := diff.Spec.Image
+	if image == "" {
+		image = app.Spec.Image
+	}
+	if _, isPattern := autoupgrade.AutoUpgradePattern(image); isPattern && image == app.Spec.Image {
+		// An auto-upgrade pattern can't be pulled, compare against the image that was resolved for it
+		image = app.Status.AppImage.ID
+	}
+
+	resolvedImage, _, err := tags.ResolveLocal(ctx, s.client, app.Namespace, image)
+	if err != nil {
+		return nil, err
+	}
+
+	appImage, err := images.PullAppImage(ctx, s.client, app.Namespace, resolvedImage, s.remoteOpt)
+	if err != nil {
+		return nil, err
+	}
+
+	appDef, err := appdefinition.FromAppImage(appImage)
+	if err != nil {
+		return nil, err
+	}
+
+	appDef, _, err = appDef.WithArgs(diff.Spec.DeployArgs, diff.Spec.GetProfiles())
+	if err != nil {
+		return nil, err
+	}
+
+	appSpec, err := appDef.AppSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	diff.Name = app.Name
+	diff.Namespace = app.Namespace
+	diff.Image = image
+	diff.Digest = appImage.Digest
+	diff.Changes = appdiff.Diff(&app.Status.AppSpec, appSpec)
+	return diff, nil
+}
+
+func (s *DiffStrategy) New() types.Object {
+	return &apiv1.AppDiff{}
+}
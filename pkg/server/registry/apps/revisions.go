@@ -0,0 +1,77 @@
+package apps
+
+import (
+	"context"
+	"sort"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	kclient "github.com/acorn-io/acorn/pkg/k8sclient"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/mink/pkg/stores"
+	"github.com/acorn-io/mink/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewRevisions(c client.WithWatch) rest.Storage {
+	return stores.NewBuilder(c.Scheme(), &apiv1.AppHistory{}).
+		WithGet(&RevisionsStrategy{
+			client: c,
+		}).
+		Build()
+}
+
+type RevisionsStrategy struct {
+	client client.WithWatch
+}
+
+func (s *RevisionsStrategy) Get(ctx context.Context, namespace, name string) (types.Object, error) {
+	app := &v1.AppInstance{}
+	err := s.client.Get(ctx, kclient.ObjectKey{Namespace: namespace, Name: name}, app)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := &v1.AppRevisionInstanceList{}
+	err = s.client.List(ctx, revisions, &client.ListOptions{
+		Namespace: app.Namespace,
+		LabelSelector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornAppName: app.Name,
+			labels.AcornAppUID:  string(app.UID),
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(revisions.Items, func(i, j int) bool {
+		return revisions.Items[i].Revision < revisions.Items[j].Revision
+	})
+
+	history := &apiv1.AppHistory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              app.Name,
+			Namespace:         app.Namespace,
+			UID:               app.UID,
+			ResourceVersion:   app.ResourceVersion,
+			CreationTimestamp: app.CreationTimestamp,
+		},
+	}
+	for _, revision := range revisions.Items {
+		history.Revisions = append(history.Revisions, apiv1.AppRevision{
+			Revision: revision.Revision,
+			Created:  revision.CreationTimestamp,
+			Spec:     revision.Spec,
+			AppImage: revision.AppImage,
+		})
+	}
+
+	return history, nil
+}
+
+func (s *RevisionsStrategy) New() types.Object {
+	return &apiv1.AppHistory{}
+}
@@ -4,22 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
 	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
 	"github.com/acorn-io/acorn/pkg/autoupgrade"
+	"github.com/acorn-io/acorn/pkg/autoupgrade/validate"
 	"github.com/acorn-io/acorn/pkg/client"
+	"github.com/acorn-io/acorn/pkg/labels"
 	"github.com/acorn-io/acorn/pkg/pullsecret"
 	"github.com/acorn-io/acorn/pkg/tags"
 	"github.com/acorn-io/baaah/pkg/merr"
 	"github.com/acorn-io/baaah/pkg/typed"
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	name2 "github.com/rancher/wrangler/pkg/name"
 	authv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apiserver/pkg/endpoints/request"
@@ -41,7 +47,14 @@ func NewValidator(client kclient.Client, clientFactory *client.Factory) *Validat
 func (s *Validator) Validate(ctx context.Context, obj runtime.Object) (result field.ErrorList) {
 	params := obj.(*apiv1.App)
 
-	if _, isPattern := autoupgrade.AutoUpgradePattern(params.Spec.Image); !isPattern {
+	if pattern, isPattern := autoupgrade.AutoUpgradePattern(params.Spec.Image); isPattern {
+		if autoupgrade.IsSemverConstraint(pattern) {
+			if _, err := validate.SemverConstraint(pattern); err != nil {
+				result = append(result, field.Invalid(field.NewPath("spec", "image"), params.Spec.Image, err.Error()))
+				return
+			}
+		}
+	} else {
 		image, local, err := s.resolveLocalImage(ctx, params.Namespace, params.Spec.Image)
 		if err != nil {
 			result = append(result, field.Invalid(field.NewPath("spec", "image"), params.Spec.Image, err.Error()))
@@ -71,12 +84,123 @@ func (s *Validator) Validate(ctx context.Context, obj runtime.Object) (result fi
 		result = append(result, field.Invalid(field.NewPath("spec", "permissions"), params.Spec.Permissions, err.Error()))
 	}
 
+	if params.Spec.AutoUpgradeRollbackAfter != "" {
+		if _, err := validate.AutoUpgradeRollbackAfter(params.Spec.AutoUpgradeRollbackAfter); err != nil {
+			result = append(result, field.Invalid(field.NewPath("spec", "autoUpgradeRollbackAfter"), params.Spec.AutoUpgradeRollbackAfter, err.Error()))
+		}
+	}
+
+	if params.Spec.AutoUpgradeMaintenanceWindow != "" {
+		if _, err := validate.MaintenanceWindow(params.Spec.AutoUpgradeMaintenanceWindow); err != nil {
+			result = append(result, field.Invalid(field.NewPath("spec", "autoUpgradeMaintenanceWindow"), params.Spec.AutoUpgradeMaintenanceWindow, err.Error()))
+		}
+	}
+
+	result = append(result, validateResourceBindings(field.NewPath("spec", "memory"), params.Spec.Memory)...)
+	result = append(result, validateResourceBindings(field.NewPath("spec", "cpu"), params.Spec.CPU)...)
+
+	for i, binding := range params.Spec.NodeSelectors {
+		if err := binding.Validate(); err != nil {
+			result = append(result, field.Invalid(field.NewPath("spec", "nodeSelectors").Index(i), binding, err.Error()))
+		}
+	}
+
 	return result
 }
 
+func validateResourceBindings(path *field.Path, bindings []v1.ResourceBinding) (result field.ErrorList) {
+	for i, binding := range bindings {
+		if err := (v1.ComputeResource{Request: binding.Request, Limit: binding.Limit}).Validate(); err != nil {
+			result = append(result, field.Invalid(path.Index(i), binding, err.Error()))
+		}
+	}
+	return
+}
+
 func (s *Validator) ValidateUpdate(ctx context.Context, obj, old runtime.Object) (result field.ErrorList) {
 	newParams := obj.(*apiv1.App)
-	return s.Validate(ctx, newParams)
+	oldParams := old.(*apiv1.App)
+	result = s.Validate(ctx, newParams)
+	return append(result, s.validateVolumeSizes(ctx, newParams, oldParams.Status.Namespace)...)
+}
+
+// validateVolumeSizes rejects volume bindings that would shrink the existing claims of a volume, as claims can only
+// be expanded
+func (s *Validator) validateVolumeSizes(ctx context.Context, app *apiv1.App, namespace string) (result field.ErrorList) {
+	for i, binding := range app.Spec.Volumes {
+		if binding.Size == "" {
+			continue
+		}
+
+		path := field.NewPath("spec", "volumes").Index(i).Child("size")
+		desired, err := resource.ParseQuantity(string(binding.Size))
+		if err != nil {
+			result = append(result, field.Invalid(path, binding.Size, err.Error()))
+			continue
+		}
+
+		if namespace == "" {
+			continue
+		}
+
+		pvcs, err := s.volumeClaims(ctx, namespace, binding)
+		if err != nil {
+			result = append(result, field.InternalError(path, err))
+			continue
+		}
+
+		for _, pvc := range pvcs {
+			volume := binding.Target
+			if pvc.Labels[labels.AcornContainerName] != "" {
+				volume = fmt.Sprintf("%s of replica %s", binding.Target, pvc.Name)
+			}
+
+			current := pvc.Spec.Resources.Requests.Storage()
+			if capacity := pvc.Status.Capacity.Storage(); capacity.Cmp(*current) > 0 {
+				current = capacity
+			}
+			if desired.Cmp(*current) < 0 {
+				result = append(result, field.Invalid(path, binding.Size,
+					fmt.Sprintf("volume %s can not be shrunk from %s to %s, volumes can only be expanded", volume, current, desired.String())))
+			}
+		}
+	}
+	return
+}
+
+// volumeClaims returns the existing claims of the volume. A volume of a stateful container has a claim for each
+// replica, named <volume>-<container>-<replica>, that its StatefulSet created.
+func (s *Validator) volumeClaims(ctx context.Context, namespace string, binding v1.VolumeBinding) (result []corev1.PersistentVolumeClaim, _ error) {
+	claimName := binding.Target
+	if binding.Volume != "" {
+		claimName = name2.SafeConcatName(binding.Target, "bind")
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := s.client.Get(ctx, kclient.ObjectKey{Namespace: namespace, Name: claimName}, pvc); err == nil {
+		result = append(result, *pvc)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if binding.Volume != "" {
+		return result, nil
+	}
+
+	var pvcs corev1.PersistentVolumeClaimList
+	if err := s.client.List(ctx, &pvcs, kclient.InNamespace(namespace), kclient.HasLabels{labels.AcornContainerName}); err != nil {
+		return nil, err
+	}
+	for _, pvc := range pvcs.Items {
+		prefix := binding.Target + "-" + pvc.Labels[labels.AcornContainerName] + "-"
+		if !strings.HasPrefix(pvc.Name, prefix) {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimPrefix(pvc.Name, prefix)); err == nil {
+			result = append(result, pvc)
+		}
+	}
+	return result, nil
 }
 
 func (s *Validator) checkRemoteAccess(ctx context.Context, namespace, image string) error {
@@ -0,0 +1,93 @@
+package apps
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func claim(name, request, capacity string) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "app-target-ns"},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse(request),
+				},
+			},
+		},
+	}
+	if capacity != "" {
+		pvc.Status.Capacity = corev1.ResourceList{
+			corev1.ResourceStorage: resource.MustParse(capacity),
+		}
+	}
+	return pvc
+}
+
+func replicaClaim(name, container, request string) *corev1.PersistentVolumeClaim {
+	pvc := claim(name, request, "")
+	pvc.Labels = map[string]string{labels.AcornContainerName: container}
+	return pvc
+}
+
+func TestValidateVolumeSizes(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		claim("data", "10G", ""),
+		claim("logs", "10G", "20G"),
+		claim("cache-bind", "10G", ""),
+		replicaClaim("db-data-db-0", "db", "10G"),
+		replicaClaim("db-data-db-1", "db", "30G"),
+		replicaClaim("db-data-db-backup", "db", "30G"),
+	).Build()
+	s := &Validator{client: c}
+
+	newApp := func(bindings ...v1.VolumeBinding) *apiv1.App {
+		return &apiv1.App{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "acorn"},
+			Spec:       v1.AppInstanceSpec{Volumes: bindings},
+		}
+	}
+
+	// Expanding is allowed
+	assert.Empty(t, s.validateVolumeSizes(context.Background(), newApp(
+		v1.VolumeBinding{Target: "data", Size: "50G"},
+		v1.VolumeBinding{Target: "logs", Size: "20G"},
+		v1.VolumeBinding{Target: "new", Size: "1G"},
+	), "app-target-ns"))
+
+	// Shrinking is not, compared to the larger of the request and the capacity
+	errs := s.validateVolumeSizes(context.Background(), newApp(
+		v1.VolumeBinding{Target: "data", Size: "5G"},
+		v1.VolumeBinding{Target: "logs", Size: "15G"},
+		v1.VolumeBinding{Volume: "existing", Target: "cache", Size: "1G"},
+	), "app-target-ns")
+	if assert.Len(t, errs, 3) {
+		assert.Equal(t, "spec.volumes[0].size", errs[0].Field)
+		assert.Contains(t, errs[0].Detail, "volume data can not be shrunk from 10G to 5G")
+		assert.Contains(t, errs[1].Detail, "volume logs can not be shrunk from 20G to 15G")
+		assert.Contains(t, errs[2].Detail, "volume cache can not be shrunk from 10G to 1G")
+	}
+
+	// Each replica claim of a stateful volume is checked
+	assert.Empty(t, s.validateVolumeSizes(context.Background(), newApp(v1.VolumeBinding{Target: "db-data", Size: "30G"}), "app-target-ns"))
+	errs = s.validateVolumeSizes(context.Background(), newApp(v1.VolumeBinding{Target: "db-data", Size: "20G"}), "app-target-ns")
+	if assert.Len(t, errs, 1) {
+		assert.Contains(t, errs[0].Detail, "volume db-data of replica db-data-db-1 can not be shrunk from 30G to 20G")
+	}
+
+	// Sizes are still parsed before the app has a namespace
+	errs = s.validateVolumeSizes(context.Background(), newApp(v1.VolumeBinding{Target: "data", Size: "big"}), "")
+	if assert.Len(t, errs, 1) {
+		assert.Equal(t, "spec.volumes[0].size", errs[0].Field)
+	}
+}
@@ -0,0 +1,46 @@
+package info
+
+import (
+	"context"
+	"errors"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/encryption"
+	"github.com/acorn-io/mink/pkg/stores"
+	"github.com/acorn-io/mink/pkg/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewKeyDeletion(c client.WithWatch) rest.Storage {
+	return stores.NewBuilder(c.Scheme(), &apiv1.EncryptionKeyDeletion{}).
+		WithCreate(&KeyDeletionStrategy{
+			client: c,
+		}).Build()
+}
+
+type KeyDeletionStrategy struct {
+	client client.WithWatch
+}
+
+func (s *KeyDeletionStrategy) Create(ctx context.Context, obj types.Object) (types.Object, error) {
+	deletion := obj.(*apiv1.EncryptionKeyDeletion)
+
+	if len(deletion.KeyIDs) == 0 {
+		return nil, apierrors.NewBadRequest("no keys to delete")
+	}
+
+	var inUse *encryption.ErrKeysInUse
+	err := encryption.DeleteKeys(ctx, s.client, deletion.Namespace, deletion.Force, deletion.KeyIDs...)
+	if errors.As(err, &inUse) {
+		return nil, apierrors.NewBadRequest(err.Error())
+	} else if err != nil {
+		return nil, err
+	}
+	return deletion, nil
+}
+
+func (s *KeyDeletionStrategy) New() types.Object {
+	return &apiv1.EncryptionKeyDeletion{}
+}
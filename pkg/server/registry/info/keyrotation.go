@@ -0,0 +1,38 @@
+package info
+
+import (
+	"context"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/encryption/nacl"
+	"github.com/acorn-io/mink/pkg/stores"
+	"github.com/acorn-io/mink/pkg/types"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewKeyRotation(c client.WithWatch) rest.Storage {
+	return stores.NewBuilder(c.Scheme(), &apiv1.EncryptionKeyRotation{}).
+		WithCreate(&KeyRotationStrategy{
+			client: c,
+		}).Build()
+}
+
+type KeyRotationStrategy struct {
+	client client.WithWatch
+}
+
+func (s *KeyRotationStrategy) Create(ctx context.Context, obj types.Object) (types.Object, error) {
+	rotation := obj.(*apiv1.EncryptionKeyRotation)
+
+	key, err := nacl.RotateNaclKey(ctx, s.client, rotation.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	rotation.PrimaryKey = key.PublicKeyID()
+	return rotation, nil
+}
+
+func (s *KeyRotationStrategy) New() types.Object {
+	return &apiv1.EncryptionKeyRotation{}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/acorn-io/acorn/pkg/server/registry/info"
 	"github.com/acorn-io/acorn/pkg/server/registry/secrets"
 	"github.com/acorn-io/acorn/pkg/server/registry/volumes"
+	"github.com/acorn-io/acorn/pkg/server/registry/volumesnapshots"
 	"github.com/acorn-io/mink/pkg/serializer"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -60,27 +61,44 @@ func APIStores(c kclient.WithWatch, cfg, localCfg *clientgo.Config) (map[string]
 
 	volumesStorage := volumes.NewStorage(c)
 
+	volumeExport, err := volumes.NewVolumeExport(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeImport, err := volumes.NewVolumeImport(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	stores := map[string]rest.Storage{
-		"acornimagebuilds":       buildsStorage,
-		"apps":                   appsStorage,
-		"apps/log":               logsStorage,
-		"apps/confirmupgrade":    apps.NewConfirmUpgrade(c),
-		"apps/pullimage":         apps.NewPullAppImage(c),
-		"builders":               buildersStorage,
-		"builders/port":          buildersPort,
-		"images":                 imagesStorage,
-		"images/tag":             images.NewTagStorage(c),
-		"images/push":            images.NewImagePush(c, transport),
-		"images/pull":            images.NewImagePull(c, clientFactory, transport),
-		"images/details":         images.NewImageDetails(c, transport),
-		"volumes":                volumesStorage,
-		"containerreplicas":      containersStorage,
-		"containerreplicas/exec": containerExec,
-		"credentials":            credentials.NewStore(c),
-		"credentials/expose":     credentials.NewExpose(c),
-		"secrets":                secrets.NewStorage(c),
-		"secrets/expose":         secrets.NewExpose(c),
-		"infos":                  info.NewStorage(c),
+		"acornimagebuilds":        buildsStorage,
+		"apps":                    appsStorage,
+		"apps/log":                logsStorage,
+		"apps/confirmupgrade":     apps.NewConfirmUpgrade(c),
+		"apps/diff":               apps.NewDiff(c, transport),
+		"apps/pullimage":          apps.NewPullAppImage(c),
+		"apps/revisions":          apps.NewRevisions(c),
+		"builders":                buildersStorage,
+		"builders/port":           buildersPort,
+		"images":                  imagesStorage,
+		"images/tag":              images.NewTagStorage(c),
+		"images/push":             images.NewImagePush(c, transport),
+		"images/pull":             images.NewImagePull(c, clientFactory, transport),
+		"images/details":          images.NewImageDetails(c, transport),
+		"volumes":                 volumesStorage,
+		"volumes/export":          volumeExport,
+		"volumes/import":          volumeImport,
+		"volumesnapshots":         volumesnapshots.NewStorage(c),
+		"volumesnapshots/restore": volumesnapshots.NewRestore(c),
+		"containerreplicas":       containersStorage,
+		"containerreplicas/exec":  containerExec,
+		"credentials":             credentials.NewStore(c),
+		"credentials/expose":      credentials.NewExpose(c),
+		"secrets":                 secrets.NewStorage(c),
+		"secrets/expose":          secrets.NewExpose(c),
+		"infos":                   info.NewStorage(c),
+		"encryptionkeyrotations":  info.NewKeyRotation(c),
 	}
 
 	return stores, nil
@@ -92,13 +92,30 @@ func (t *Translator) ToPublic(objs ...runtime.Object) (result []types.Object) {
 		}
 		sec.UID = sec.UID + "-s"
 		if t.expose {
-			sec.Data = secret.Data
+			sec.Data = exposedData(secret)
 		}
 		result = append(result, sec)
 	}
 	return
 }
 
+// exposedData returns the data of a secret without its private key, so the public half of a generated key can be read
+// without revealing the private key.
+func exposedData(secret *corev1.Secret) map[string][]byte {
+	privateKey, ok := v1.SecretPrivateKeys[secret.Type]
+	if !ok {
+		return secret.Data
+	}
+
+	data := make(map[string][]byte, len(secret.Data))
+	for k, v := range secret.Data {
+		if k != privateKey {
+			data[k] = v
+		}
+	}
+	return data
+}
+
 func (t *Translator) FromPublic(ctx context.Context, obj runtime.Object) (types.Object, error) {
 	secret := obj.(*apiv1.Secret)
 	if secret.Data == nil {
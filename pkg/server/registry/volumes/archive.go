@@ -0,0 +1,282 @@
+package volumes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"path"
+	"time"
+
+	api "github.com/acorn-io/acorn/pkg/apis/api.acorn.io"
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/acorn-io/baaah/pkg/restconfig"
+	"github.com/acorn-io/baaah/pkg/watcher"
+	"github.com/acorn-io/mink/pkg/strategy"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	registryrest "k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	archiveContainer = "archive"
+	archiveMountPath = "/data"
+	// archiveUser reads and writes the files of the volume in namespaces that only allow pods that don't run as root
+	archiveUser = 1000
+
+	podSecurityEnforceLabel = "pod-security.kubernetes.io/enforce"
+	podSecurityRestricted   = "restricted"
+)
+
+// VolumeArchive streams a volume as a tar archive from, or into, a short-lived pod that mounts the claim of the volume
+type VolumeArchive struct {
+	*strategy.DestroyAdapter
+	client     kclient.WithWatch
+	proxy      httputil.ReverseProxy
+	RESTClient rest.Interface
+	k8s        kubernetes.Interface
+	extract    bool
+}
+
+func NewVolumeExport(c kclient.WithWatch, cfg *rest.Config) (*VolumeArchive, error) {
+	return newVolumeArchive(c, cfg, false)
+}
+
+func NewVolumeImport(c kclient.WithWatch, cfg *rest.Config) (*VolumeArchive, error) {
+	return newVolumeArchive(c, cfg, true)
+}
+
+func newVolumeArchive(c kclient.WithWatch, cfg *rest.Config, extract bool) (*VolumeArchive, error) {
+	cfg = rest.CopyConfig(cfg)
+	restconfig.SetScheme(cfg, scheme.Scheme)
+
+	k8s, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VolumeArchive{
+		k8s:    k8s,
+		client: c,
+		proxy: httputil.ReverseProxy{
+			FlushInterval: 200 * time.Millisecond,
+			Transport:     transport,
+			Director:      func(request *http.Request) {},
+		},
+		RESTClient: k8s.CoreV1().RESTClient(),
+		extract:    extract,
+	}, nil
+}
+
+func (v *VolumeArchive) New() runtime.Object {
+	return &apiv1.VolumeArchiveOptions{}
+}
+
+func (v *VolumeArchive) NewConnectOptions() (runtime.Object, bool, string) {
+	return &apiv1.VolumeArchiveOptions{}, false, ""
+}
+
+func (v *VolumeArchive) ConnectMethods() []string {
+	return []string{"GET"}
+}
+
+func (v *VolumeArchive) Connect(ctx context.Context, id string, options runtime.Object, r registryrest.Responder) (http.Handler, error) {
+	opts := options.(*apiv1.VolumeArchiveOptions)
+	ns, _ := request.NamespaceFrom(ctx)
+
+	// Cleaning the sub path as an absolute path ensures it stays in the volume
+	dir := path.Join(archiveMountPath, path.Clean("/"+opts.SubPath))
+
+	pv := &corev1.PersistentVolume{}
+	if err := v.client.Get(ctx, kclient.ObjectKey{Name: id}, pv); apierrors.IsNotFound(err) ||
+		(err == nil && (pv.Labels[labels.AcornManaged] != "true" || pv.Labels[labels.AcornAppNamespace] != ns)) {
+		return nil, apierrors.NewNotFound(schema.GroupResource{
+			Group:    api.Group,
+			Resource: "volumes",
+		}, id)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if pv.Spec.ClaimRef == nil || pv.Status.Phase != corev1.VolumeBound {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("volume %s is not bound to an app", pv.Name))
+	}
+
+	pod, err := v.startPod(ctx, pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	command := []string{"tar", "-C", dir, "-cf", "-", "."}
+	if v.extract {
+		// The sub path to import to may not exist yet
+		command = []string{"sh", "-c", `mkdir -p "$1" && tar -C "$1" -xf -`, "sh", dir}
+	}
+
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		req := v.RESTClient.Get().
+			Namespace(pod.Namespace).
+			Resource("pods").
+			Name(pod.Name).
+			SubResource("exec").
+			VersionedParams(&corev1.PodExecOptions{
+				Stdin:     v.extract,
+				Stdout:    true,
+				Stderr:    true,
+				Container: archiveContainer,
+				Command:   command,
+			}, scheme.ParameterCodec)
+		request.URL = req.URL()
+		defer v.deletePod(pod)
+		v.proxy.ServeHTTP(writer, request)
+	}), nil
+}
+
+// startPod starts a pod mounting the claim and waits for it to run. The pod is scheduled on the node of a running pod
+// that already mounts the claim, as the volume can typically only be attached to one node.
+func (v *VolumeArchive) startPod(ctx context.Context, namespace, claimName string) (*corev1.Pod, error) {
+	pods := v.k8s.CoreV1().Pods(namespace)
+
+	existing, err := pods.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var nodeName string
+	for _, pod := range existing.Items {
+		if pod.Status.Phase == corev1.PodRunning && mountsClaim(pod, claimName) {
+			nodeName = pod.Spec.NodeName
+			break
+		}
+	}
+
+	ns, err := v.k8s.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := pods.Create(ctx, archivePod(claimName, nodeName, v.extract,
+		ns.Labels[podSecurityEnforceLabel] == podSecurityRestricted), metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	running, err := watcher.New[*corev1.Pod](v.client).ByObject(ctx, pod, func(pod *corev1.Pod) (bool, error) {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == archiveContainer {
+				if status.State.Running != nil {
+					return true, nil
+				} else if status.State.Terminated != nil {
+					return false, fmt.Errorf("%s: %s", status.State.Terminated.Reason, status.State.Terminated.Message)
+				}
+			}
+		}
+		return false, nil
+	})
+	if err != nil {
+		v.deletePod(pod)
+		return nil, err
+	}
+	return running, nil
+}
+
+// deletePod removes the pod once the archive is streamed, the sleep of the pod only stops it if the deletion failed
+func (v *VolumeArchive) deletePod(pod *corev1.Pod) {
+	err := v.k8s.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		logrus.Errorf("failed to delete volume archive pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+}
+
+// archivePod returns the pod that mounts the claim to stream it. The files of a volume are owned by the users of the
+// app, so tar runs as root with only the capabilities it needs to read, and to restore the owners and modes of, any
+// file. Namespaces with the restricted PodSecurity profile don't allow root, there it runs as archiveUser and can only
+// access the files that user can.
+func archivePod(claimName, nodeName string, extract, restricted bool) *corev1.Pod {
+	securityContext := &corev1.SecurityContext{
+		RunAsUser:                new(int64),
+		RunAsNonRoot:             new(bool),
+		ReadOnlyRootFilesystem:   &[]bool{true}[0],
+		AllowPrivilegeEscalation: new(bool),
+		Capabilities: &corev1.Capabilities{
+			Add:  []corev1.Capability{"DAC_OVERRIDE"},
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+	if extract {
+		securityContext.Capabilities.Add = append(securityContext.Capabilities.Add, "CHOWN", "FOWNER", "FSETID")
+	}
+	if restricted {
+		securityContext.RunAsUser = &[]int64{archiveUser}[0]
+		securityContext.RunAsNonRoot = &[]bool{true}[0]
+		securityContext.Capabilities.Add = nil
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "volume-archive-",
+			Labels: map[string]string{
+				labels.AcornManaged:      "true",
+				labels.AcornVolumeHelper: "true",
+			},
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{
+				{
+					Name:            archiveContainer,
+					Image:           system.DefaultImage(),
+					Command:         []string{"sleep", "3600"},
+					SecurityContext: securityContext,
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "data",
+							MountPath: archiveMountPath,
+							ReadOnly:  !extract,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "data",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: claimName,
+							ReadOnly:  !extract,
+						},
+					},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+		},
+	}
+}
+
+func mountsClaim(pod corev1.Pod, claimName string) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == claimName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package volumes
+
+import (
+	"testing"
+
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestArchivePod(t *testing.T) {
+	pod := archivePod("data", "node1", false, false)
+	assert.Equal(t, "true", pod.Labels[labels.AcornVolumeHelper])
+	assert.Equal(t, "node1", pod.Spec.NodeName)
+	assert.Equal(t, "data", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.True(t, pod.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly)
+
+	container := pod.Spec.Containers[0]
+	assert.True(t, container.VolumeMounts[0].ReadOnly)
+	assert.Equal(t, &corev1.SecurityContext{
+		RunAsUser:                new(int64),
+		RunAsNonRoot:             new(bool),
+		ReadOnlyRootFilesystem:   &[]bool{true}[0],
+		AllowPrivilegeEscalation: new(bool),
+		Capabilities: &corev1.Capabilities{
+			Add:  []corev1.Capability{"DAC_OVERRIDE"},
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}, container.SecurityContext)
+}
+
+func TestArchivePodExtract(t *testing.T) {
+	pod := archivePod("data", "", true, false)
+	assert.False(t, pod.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly)
+
+	container := pod.Spec.Containers[0]
+	assert.False(t, container.VolumeMounts[0].ReadOnly)
+	assert.Equal(t, []corev1.Capability{"DAC_OVERRIDE", "CHOWN", "FOWNER", "FSETID"}, container.SecurityContext.Capabilities.Add)
+	assert.Equal(t, []corev1.Capability{"ALL"}, container.SecurityContext.Capabilities.Drop)
+}
+
+func TestArchivePodRestricted(t *testing.T) {
+	container := archivePod("data", "", true, true).Spec.Containers[0]
+	assert.Equal(t, int64(archiveUser), *container.SecurityContext.RunAsUser)
+	assert.True(t, *container.SecurityContext.RunAsNonRoot)
+	assert.False(t, *container.SecurityContext.AllowPrivilegeEscalation)
+	assert.Empty(t, container.SecurityContext.Capabilities.Add)
+	assert.Equal(t, []corev1.Capability{"ALL"}, container.SecurityContext.Capabilities.Drop)
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, container.SecurityContext.SeccompProfile.Type)
+}
@@ -73,6 +73,7 @@ func pvToVolume(pv corev1.PersistentVolume) *apiv1.Volume {
 			AppNamespace: pv.Labels[labels.AcornAppNamespace],
 			VolumeName:   pv.Labels[labels.AcornVolumeName],
 			Status:       strings.ToLower(string(pv.Status.Phase)),
+			Resize:       pv.Annotations[labels.AcornVolumeResizeStatus],
 			Columns: apiv1.VolumeColumns{
 				AccessModes: strings.Join(shortAccessModes, ","),
 			},
@@ -80,6 +81,11 @@ func pvToVolume(pv corev1.PersistentVolume) *apiv1.Volume {
 	}
 	vol.UID = vol.UID + "-v"
 	vol.Namespace = pv.Labels[labels.AcornAppNamespace]
+	if strings.HasPrefix(vol.Status.Resize, "error:") {
+		vol.Status.Status += "/resize-failed"
+	} else if vol.Status.Resize != "" {
+		vol.Status.Status += "/resizing"
+	}
 	if !pv.DeletionTimestamp.IsZero() {
 		vol.Status.Status += "/deleted"
 	}
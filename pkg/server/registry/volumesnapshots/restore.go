@@ -0,0 +1,143 @@
+package volumesnapshots
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	v1 "github.com/acorn-io/acorn/pkg/apis/internal.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/mink/pkg/stores"
+	"github.com/acorn-io/mink/pkg/types"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	name2 "github.com/rancher/wrangler/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewRestore(c kclient.WithWatch) rest.Storage {
+	return stores.NewBuilder(c.Scheme(), &apiv1.VolumeSnapshotRestore{}).
+		WithCreate(&RestoreStrategy{
+			client: c,
+		}).Build()
+}
+
+type RestoreStrategy struct {
+	client kclient.WithWatch
+}
+
+// Create restores the snapshot to a new claim next to the snapshot. Once the claim is bound, the controller deletes
+// it, leaving a volume that can be bound to an app by the requested name.
+func (s *RestoreStrategy) Create(ctx context.Context, obj types.Object) (types.Object, error) {
+	restore := obj.(*apiv1.VolumeSnapshotRestore)
+	ri, _ := request.RequestInfoFrom(ctx)
+
+	if ri.Name == "" || ri.Namespace == "" {
+		return restore, nil
+	}
+
+	if errs := validation.IsDNS1123Label(restore.VolumeName); len(errs) > 0 {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid volume name %q: %v", restore.VolumeName, errs))
+	}
+
+	snapshot, err := getSnapshot(ctx, s.client, ri.Namespace, ri.Name)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Status == nil || snapshot.Status.ReadyToUse == nil || !*snapshot.Status.ReadyToUse {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("snapshot %s is not ready to use", snapshot.Name))
+	}
+
+	if err := s.checkVolumeName(ctx, ri.Namespace, restore.VolumeName); err != nil {
+		return nil, err
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name2.SafeConcatName(restore.VolumeName, "restore"),
+			Namespace: snapshot.Namespace,
+			Labels: map[string]string{
+				labels.AcornManaged:        "true",
+				labels.AcornAppNamespace:   ri.Namespace,
+				labels.AcornRestoredVolume: restore.VolumeName,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: &snapshotv1.SchemeGroupVersion.Group,
+				Kind:     "VolumeSnapshot",
+				Name:     snapshot.Name,
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: *v1.DefaultSize,
+				},
+			},
+		},
+	}
+
+	// Restore to the same class and access modes as the volume the snapshot was taken of
+	source := &corev1.PersistentVolume{}
+	if err := s.client.Get(ctx, kclient.ObjectKey{Name: snapshot.Labels[labels.AcornSnapshotVolume]}, source); err == nil {
+		if len(source.Spec.AccessModes) > 0 {
+			pvc.Spec.AccessModes = source.Spec.AccessModes
+		}
+		if source.Spec.StorageClassName != "" {
+			pvc.Spec.StorageClassName = &source.Spec.StorageClassName
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	if snapshot.Status.RestoreSize != nil && !snapshot.Status.RestoreSize.IsZero() {
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *snapshot.Status.RestoreSize
+	}
+
+	if err := s.client.Create(ctx, pvc); err != nil {
+		return nil, err
+	}
+
+	return restore, nil
+}
+
+// checkVolumeName ensures the name of the restored volume is not used by another volume of the project
+func (s *RestoreStrategy) checkVolumeName(ctx context.Context, namespace, name string) error {
+	pv := &corev1.PersistentVolume{}
+	if err := s.client.Get(ctx, kclient.ObjectKey{Name: name}, pv); err == nil {
+		return apierrors.NewAlreadyExists(groupResource("volumes"), name)
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	sel := kclient.MatchingLabelsSelector{
+		Selector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornAppNamespace:   namespace,
+			labels.AcornRestoredVolume: name,
+		}),
+	}
+
+	pvs := &corev1.PersistentVolumeList{}
+	if err := s.client.List(ctx, pvs, sel); err != nil {
+		return err
+	}
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := s.client.List(ctx, pvcs, sel); err != nil {
+		return err
+	}
+	if len(pvs.Items) > 0 || len(pvcs.Items) > 0 {
+		return apierrors.NewAlreadyExists(groupResource("volumes"), name)
+	}
+
+	return nil
+}
+
+func (s *RestoreStrategy) New() types.Object {
+	return &apiv1.VolumeSnapshotRestore{}
+}
@@ -0,0 +1,28 @@
+package volumesnapshots
+
+import (
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/tables"
+	"github.com/acorn-io/mink/pkg/stores"
+	"github.com/acorn-io/mink/pkg/strategy/remote"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	"k8s.io/apiserver/pkg/registry/rest"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewStorage(c kclient.WithWatch) rest.Storage {
+	remoteResource := remote.NewWithTranslation(&Translator{
+		c: c,
+	}, &snapshotv1.VolumeSnapshot{}, c)
+
+	return stores.NewBuilder(c.Scheme(), &apiv1.VolumeSnapshot{}).
+		WithCreate(&Strategy{
+			client: c,
+		}).
+		WithGet(remoteResource).
+		WithList(remoteResource).
+		WithDelete(remoteResource).
+		WithWatch(remoteResource).
+		WithTableConverter(tables.VolumeSnapshotConverter).
+		Build()
+}
@@ -0,0 +1,76 @@
+package volumesnapshots
+
+import (
+	"context"
+	"fmt"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/mink/pkg/types"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Strategy struct {
+	client kclient.Client
+}
+
+// Create takes a snapshot of the claim the volume is bound to. The snapshot is created in the namespace of the
+// claim, which is the namespace of the app using the volume.
+func (s *Strategy) Create(ctx context.Context, obj types.Object) (types.Object, error) {
+	snapshot := obj.(*apiv1.VolumeSnapshot)
+	if snapshot.Spec.Volume == "" {
+		return nil, apierrors.NewBadRequest("volume is required to take a snapshot")
+	}
+
+	pv := &corev1.PersistentVolume{}
+	if err := s.client.Get(ctx, kclient.ObjectKey{Name: snapshot.Spec.Volume}, pv); apierrors.IsNotFound(err) ||
+		(err == nil && (pv.Labels[labels.AcornManaged] != "true" || pv.Labels[labels.AcornAppNamespace] != snapshot.Namespace)) {
+		return nil, apierrors.NewNotFound(groupResource("volumes"), snapshot.Spec.Volume)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if pv.Spec.ClaimRef == nil || pv.Status.Phase != corev1.VolumeBound {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("volume %s is not bound to an app", pv.Name))
+	}
+
+	// Snapshots are looked up by name in all namespaces of the project
+	if _, err := getSnapshot(ctx, s.client, snapshot.Namespace, snapshot.Name); err == nil {
+		return nil, apierrors.NewAlreadyExists(groupResource("volumesnapshots"), snapshot.Name)
+	} else if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	result := &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      snapshot.Name,
+			Namespace: pv.Spec.ClaimRef.Namespace,
+			Labels: labels.Merge(labels.ExcludeAcornKey(snapshot.Labels), map[string]string{
+				labels.AcornManaged:        "true",
+				labels.AcornAppNamespace:   snapshot.Namespace,
+				labels.AcornAppName:        pv.Labels[labels.AcornAppName],
+				labels.AcornVolumeName:     pv.Labels[labels.AcornVolumeName],
+				labels.AcornSnapshotVolume: pv.Name,
+			}),
+			Annotations: labels.ExcludeAcornKey(snapshot.Annotations),
+		},
+		Spec: snapshotv1.VolumeSnapshotSpec{
+			Source: snapshotv1.VolumeSnapshotSource{
+				PersistentVolumeClaimName: &pv.Spec.ClaimRef.Name,
+			},
+		},
+	}
+
+	if err := s.client.Create(ctx, result); err != nil {
+		return nil, err
+	}
+	return snapshotToPublic(result), nil
+}
+
+func (s *Strategy) New() types.Object {
+	return &apiv1.VolumeSnapshot{}
+}
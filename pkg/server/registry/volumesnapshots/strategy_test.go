@@ -0,0 +1,147 @@
+package volumesnapshots
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/endpoints/request"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func boundVolume(name, namespace string) *corev1.PersistentVolume {
+	return &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				labels.AcornManaged:      "true",
+				labels.AcornAppName:      "app",
+				labels.AcornAppNamespace: namespace,
+				labels.AcornVolumeName:   "data",
+			},
+		},
+		Spec: corev1.PersistentVolumeSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			StorageClassName: "csi-class",
+			ClaimRef: &corev1.ObjectReference{
+				Name:      "data",
+				Namespace: "app-target-ns",
+			},
+		},
+		Status: corev1.PersistentVolumeStatus{
+			Phase: corev1.VolumeBound,
+		},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		boundVolume("pvc-1", "acorn"),
+		boundVolume("pvc-2", "other-project"),
+	).Build()
+	s := &Strategy{client: c}
+
+	newSnapshot := func(volume string) *apiv1.VolumeSnapshot {
+		return &apiv1.VolumeSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "acorn"},
+			Spec:       apiv1.VolumeSnapshotSpec{Volume: volume},
+		}
+	}
+
+	obj, err := s.Create(context.Background(), newSnapshot("pvc-1"))
+	require.NoError(t, err)
+	public := obj.(*apiv1.VolumeSnapshot)
+	assert.Equal(t, "acorn", public.Namespace)
+	assert.Equal(t, "pvc-1", public.Spec.Volume)
+	assert.Equal(t, "app", public.Status.AppName)
+	assert.Equal(t, "data", public.Status.VolumeName)
+	assert.Equal(t, "pending", public.Status.Status)
+
+	snapshot := &snapshotv1.VolumeSnapshot{}
+	require.NoError(t, c.Get(context.Background(), kclient.ObjectKey{Namespace: "app-target-ns", Name: "backup"}, snapshot))
+	assert.Equal(t, "data", *snapshot.Spec.Source.PersistentVolumeClaimName)
+
+	_, err = s.Create(context.Background(), newSnapshot("pvc-1"))
+	assert.True(t, apierrors.IsAlreadyExists(err))
+
+	// Volumes of other projects can't be found
+	_, err = s.Create(context.Background(), newSnapshot("pvc-2"))
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func readySnapshot() *snapshotv1.VolumeSnapshot {
+	ready := true
+	size := resource.MustParse("2Gi")
+	return &snapshotv1.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup",
+			Namespace: "app-target-ns",
+			Labels: map[string]string{
+				labels.AcornManaged:        "true",
+				labels.AcornAppNamespace:   "acorn",
+				labels.AcornSnapshotVolume: "pvc-1",
+			},
+		},
+		Status: &snapshotv1.VolumeSnapshotStatus{
+			ReadyToUse:  &ready,
+			RestoreSize: &size,
+		},
+	}
+}
+
+func restoreContext(name string) context.Context {
+	return request.WithRequestInfo(context.Background(), &request.RequestInfo{
+		Namespace: "acorn",
+		Name:      name,
+	})
+}
+
+func TestRestore(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		boundVolume("pvc-1", "acorn"),
+		readySnapshot(),
+	).Build()
+	s := &RestoreStrategy{client: c}
+
+	_, err := s.Create(restoreContext("backup"), &apiv1.VolumeSnapshotRestore{VolumeName: "db-data"})
+	require.NoError(t, err)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	require.NoError(t, c.Get(context.Background(), kclient.ObjectKey{Namespace: "app-target-ns", Name: "db-data-restore"}, pvc))
+	assert.Equal(t, "db-data", pvc.Labels[labels.AcornRestoredVolume])
+	assert.Equal(t, "acorn", pvc.Labels[labels.AcornAppNamespace])
+	assert.Equal(t, "VolumeSnapshot", pvc.Spec.DataSource.Kind)
+	assert.Equal(t, "backup", pvc.Spec.DataSource.Name)
+	assert.Equal(t, "csi-class", *pvc.Spec.StorageClassName)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, pvc.Spec.AccessModes)
+	assert.Equal(t, "2Gi", pvc.Spec.Resources.Requests.Storage().String())
+
+	_, err = s.Create(restoreContext("backup"), &apiv1.VolumeSnapshotRestore{VolumeName: "db-data"})
+	assert.True(t, apierrors.IsAlreadyExists(err))
+
+	_, err = s.Create(restoreContext("backup"), &apiv1.VolumeSnapshotRestore{VolumeName: "Invalid_Name"})
+	assert.True(t, apierrors.IsBadRequest(err))
+
+	_, err = s.Create(restoreContext("missing"), &apiv1.VolumeSnapshotRestore{VolumeName: "other"})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestRestoreNotReady(t *testing.T) {
+	snapshot := readySnapshot()
+	snapshot.Status.ReadyToUse = nil
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(snapshot).Build()
+	s := &RestoreStrategy{client: c}
+
+	_, err := s.Create(restoreContext("backup"), &apiv1.VolumeSnapshotRestore{VolumeName: "db-data"})
+	assert.ErrorContains(t, err, "snapshot backup is not ready to use")
+}
@@ -0,0 +1,134 @@
+package volumesnapshots
+
+import (
+	"context"
+	"strings"
+
+	api "github.com/acorn-io/acorn/pkg/apis/api.acorn.io"
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/labels"
+	"github.com/acorn-io/mink/pkg/types"
+	snapshotv1 "github.com/kubernetes-csi/external-snapshotter/client/v4/apis/volumesnapshot/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	klabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/selection"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/storage"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type Translator struct {
+	c kclient.Client
+}
+
+func (t *Translator) FromPublicName(ctx context.Context, namespace, name string) (string, string, error) {
+	snapshot, err := getSnapshot(ctx, t.c, namespace, name)
+	if err != nil {
+		return "", "", err
+	}
+	return snapshot.Namespace, snapshot.Name, nil
+}
+
+// getSnapshot finds the snapshot by name in the namespaces of the apps of the project namespace
+func getSnapshot(ctx context.Context, c kclient.Client, namespace, name string) (*snapshotv1.VolumeSnapshot, error) {
+	snapshots := &snapshotv1.VolumeSnapshotList{}
+	err := c.List(ctx, snapshots, &kclient.ListOptions{
+		LabelSelector: klabels.SelectorFromSet(map[string]string{
+			labels.AcornManaged:      "true",
+			labels.AcornAppNamespace: namespace,
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, snapshot := range snapshots.Items {
+		if snapshot.Name == name {
+			return &snapshot, nil
+		}
+	}
+
+	return nil, apierrors.NewNotFound(groupResource("volumesnapshots"), name)
+}
+
+func groupResource(resource string) schema.GroupResource {
+	return schema.GroupResource{
+		Group:    api.Group,
+		Resource: resource,
+	}
+}
+
+func (t *Translator) ListOpts(namespace string, opts storage.ListOptions) (string, storage.ListOptions) {
+	sel := opts.Predicate.Label
+	if sel == nil {
+		sel = klabels.Everything()
+	}
+	req, _ := klabels.NewRequirement(labels.AcornManaged, selection.Equals, []string{"true"})
+	sel = sel.Add(*req)
+
+	if namespace != "" {
+		req, _ := klabels.NewRequirement(labels.AcornAppNamespace, selection.Equals, []string{namespace})
+		sel = sel.Add(*req)
+	}
+	opts.Predicate.Label = sel
+	return "", opts
+}
+
+func snapshotToPublic(snapshot *snapshotv1.VolumeSnapshot) *apiv1.VolumeSnapshot {
+	result := &apiv1.VolumeSnapshot{
+		ObjectMeta: snapshot.ObjectMeta,
+		Spec: apiv1.VolumeSnapshotSpec{
+			Volume: snapshot.Labels[labels.AcornSnapshotVolume],
+		},
+		Status: apiv1.VolumeSnapshotStatus{
+			AppName:           snapshot.Labels[labels.AcornAppName],
+			VolumeName:        snapshot.Labels[labels.AcornVolumeName],
+			SnapshotNamespace: snapshot.Namespace,
+			Status:            "pending",
+		},
+	}
+	result.UID = result.UID + "-s"
+	result.Namespace = snapshot.Labels[labels.AcornAppNamespace]
+
+	if status := snapshot.Status; status != nil {
+		result.Status.RestoreSize = status.RestoreSize
+		if status.ReadyToUse != nil && *status.ReadyToUse {
+			result.Status.ReadyToUse = true
+			result.Status.Status = "ready"
+		} else if status.Error != nil && status.Error.Message != nil {
+			result.Status.Status = "error: " + *status.Error.Message
+		}
+	}
+	if !snapshot.DeletionTimestamp.IsZero() {
+		result.Status.Status += "/deleted"
+	}
+
+	return result
+}
+
+func (t *Translator) ToPublic(objs ...runtime.Object) (result []types.Object) {
+	for _, obj := range objs {
+		result = append(result, snapshotToPublic(obj.(*snapshotv1.VolumeSnapshot)))
+	}
+	return
+}
+
+func (t *Translator) FromPublic(ctx context.Context, obj runtime.Object) (types.Object, error) {
+	snapshot := obj.(*apiv1.VolumeSnapshot)
+	result := &snapshotv1.VolumeSnapshot{
+		ObjectMeta: snapshot.ObjectMeta,
+	}
+	result.Namespace = snapshot.Status.SnapshotNamespace
+	result.UID = ktypes.UID(strings.TrimSuffix(string(result.UID), "-s"))
+	return result, nil
+}
+
+func (t *Translator) NewPublic() types.Object {
+	return &apiv1.VolumeSnapshot{}
+}
+
+func (t *Translator) NewPublicList() types.ObjectList {
+	return &apiv1.VolumeSnapshotList{}
+}
@@ -2,10 +2,14 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"time"
 
 	api "github.com/acorn-io/acorn/pkg/apis/api.acorn.io"
+	"github.com/acorn-io/acorn/pkg/autoupgrade/webhook"
 	kclient "github.com/acorn-io/acorn/pkg/k8sclient"
 	openapi2 "github.com/acorn-io/acorn/pkg/openapi"
 	"github.com/acorn-io/acorn/pkg/scheme"
@@ -13,7 +17,9 @@ import (
 	"github.com/acorn-io/baaah/pkg/clientaggregator"
 	"github.com/acorn-io/baaah/pkg/restconfig"
 	"github.com/rancher/wrangler/pkg/merr"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
+	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/endpoints/openapi"
 	"k8s.io/apiserver/pkg/server"
@@ -24,7 +30,8 @@ import (
 )
 
 type Server struct {
-	Options *options.RecommendedOptions
+	Options             *options.RecommendedOptions
+	RegistryWebhookPort int
 }
 
 type Config struct {
@@ -34,6 +41,8 @@ type Config struct {
 
 func (s *Server) AddFlags(fs *pflag.FlagSet) {
 	s.Options.AddFlags(fs)
+	fs.IntVar(&s.RegistryWebhookPort, "registry-webhook-port", 0,
+		"Also serve the registry webhook over plain HTTP on this port, for registries that can't verify the api-server certificate. 0 to disable")
 }
 
 func (s *Server) NewConfig(version string) (*Config, error) {
@@ -64,17 +73,27 @@ func (s *Server) Run(ctx context.Context, config *Config) error {
 		return merr.NewErrors(errs...)
 	}
 
-	server, err := config.Complete().New("acorn", server.NewEmptyDelegate())
+	cfg, err := restconfig.New(scheme.Scheme)
 	if err != nil {
 		return err
 	}
 
-	cfg, err := restconfig.New(scheme.Scheme)
+	c, err := kclient.New(cfg)
 	if err != nil {
 		return err
 	}
 
-	c, err := kclient.New(cfg)
+	// The registry webhook authenticates requests with its own token, so it is served in front of the Kubernetes
+	// authentication that the rest of the api-server requires
+	registryWebhook := webhook.NewHandler(c)
+	config.BuildHandlerChainFunc = func(apiHandler http.Handler, serverConfig *server.Config) http.Handler {
+		mux := http.NewServeMux()
+		mux.Handle(webhook.Path, registryWebhook)
+		mux.Handle("/", server.DefaultBuildHandlerChain(apiHandler, serverConfig))
+		return mux
+	}
+
+	server, err := config.Complete().New("acorn", server.NewEmptyDelegate())
 	if err != nil {
 		return err
 	}
@@ -101,7 +120,40 @@ func (s *Server) Run(ctx context.Context, config *Config) error {
 		return err
 	}
 
-	return server.PrepareRun().Run(ctx.Done())
+	eg, ctx := errgroup.WithContext(ctx)
+	if s.RegistryWebhookPort != 0 {
+		serveRegistryWebhook(ctx, eg, s.RegistryWebhookPort, registryWebhook)
+	}
+	eg.Go(func() error {
+		return server.PrepareRun().Run(ctx.Done())
+	})
+	return eg.Wait()
+}
+
+// serveRegistryWebhook serves the registry webhook over plain HTTP until ctx is done. If the webhook can't be
+// served, the error is returned from the group, which stops the api-server too.
+func serveRegistryWebhook(ctx context.Context, eg *errgroup.Group, port int, handler http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle(webhook.Path, handler)
+	webhookServer := &http.Server{
+		Addr:              fmt.Sprintf("0.0.0.0:%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	eg.Go(func() error {
+		logrus.Infof("Serving the registry webhook on %s", webhookServer.Addr)
+		if err := webhookServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return fmt.Errorf("serving the registry webhook on %s: %w", webhookServer.Addr, err)
+		}
+		return nil
+	})
+	eg.Go(func() error {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return webhookServer.Shutdown(shutdownCtx)
+	})
 }
 
 func New() *Server {
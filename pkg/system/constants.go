@@ -10,6 +10,8 @@ const (
 	LEAccountSecretName  = "acorn-le-account"
 	DefaultUserNamespace = "acorn"
 	DNSSecretName        = "acorn-dns"
+	RegistryWebhookName  = "acorn-registry-webhook"
+	VaultSecretName      = "acorn-vault"
 )
 
 var (
@@ -12,12 +12,28 @@ var (
 		{"Image", "{{ trunc .Status.AppImage.Name }}"},
 		{"Healthy", "Status.Columns.Healthy"},
 		{"Up-To-Date", "Status.Columns.UpToDate"},
+		{"Replicas", "Status.Columns.Replicas"},
 		{"Created", "{{ago .CreationTimestamp}}"},
 		{"Endpoints", "Status.Columns.Endpoints"},
 		{"Message", "{{ appGeneration . .Status.Columns.Message }}"},
 	}
 	AppConverter = MustConverter(App)
 
+	AppSpecChange = [][]string{
+		{"Action", "Action"},
+		{"Kind", "Kind"},
+		{"Path", "Path"},
+		{"Old", "Old"},
+		{"New", "New"},
+	}
+
+	AppRevision = [][]string{
+		{"Revision", "Revision"},
+		{"Image", "{{ trunc .AppImage.Name }}"},
+		{"Digest", "AppImage.Digest"},
+		{"Created", "{{ago .Created}}"},
+	}
+
 	Volume = [][]string{
 		{"Name", "{{ . | name }}"},
 		{"App-Name", "Status.AppName"},
@@ -29,6 +45,17 @@ var (
 	}
 	VolumeConverter = MustConverter(Volume)
 
+	VolumeSnapshot = [][]string{
+		{"Name", "{{ . | name }}"},
+		{"Volume", "Spec.Volume"},
+		{"App-Name", "Status.AppName"},
+		{"Bound-Volume", "Status.VolumeName"},
+		{"Size", "Status.RestoreSize"},
+		{"Status", "Status.Status"},
+		{"Created", "{{ago .CreationTimestamp}}"},
+	}
+	VolumeSnapshotConverter = MustConverter(VolumeSnapshot)
+
 	//Used for acorn image related printing
 	ImageAcorn = [][]string{
 		{"Repository", "{{if eq .Repository \"\"}}<none>{{else}}{{.Repository}}{{end}}"},
@@ -85,6 +112,18 @@ var (
 	}
 	InfoConverter = MustConverter(Info)
 
+	EncryptionKey = [][]string{
+		{"Key", "KeyID"},
+		{"Primary", "{{boolToStar .Primary}}"},
+		{"Created", "{{if .CreatedAt}}{{ago .CreatedAt}}{{end}}"},
+	}
+
+	RetiredKeyReference = [][]string{
+		{"App", "App"},
+		{"Field", "Field"},
+		{"Keys", "{{array .KeyIDs}}"},
+	}
+
 	Builder = [][]string{
 		{"Name", "Name"},
 		{"Ready", "Status.Ready"},
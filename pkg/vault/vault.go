@@ -0,0 +1,183 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/acorn-io/acorn/pkg/config"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/acorn-io/baaah/pkg/router"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServiceAccountTokenFile is the token of the controller's service account that is used to log in with the
+// Kubernetes auth method
+var ServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+const (
+	// maxBodySize limits the size of a response read from Vault
+	maxBodySize = 1 << 20
+	// namespaceVariable is replaced in the path prefix with the namespace of the project reading a secret
+	namespaceVariable = "{{.Namespace}}"
+)
+
+// httpClient times out requests to a Vault server that doesn't respond, so they don't block the controller
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+type response struct {
+	Data struct {
+		Data map[string]any `json:"data,omitempty"`
+	} `json:"data,omitempty"`
+	Auth struct {
+		ClientToken string `json:"client_token,omitempty"`
+	} `json:"auth,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Read returns the values of the latest version of a secret in a KV version 2 engine for the project in namespace.
+// The path is the API path of the secret, such as kv/data/db for the secret db in the engine mounted at kv. All
+// projects read with the same Vault credentials, so the path must be under the path prefix of the project. The Vault
+// server and how to log in to it are read from the acorn config.
+func Read(ctx context.Context, c kclient.Reader, namespace, secretPath string) (map[string][]byte, error) {
+	cfg, err := config.Get(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	address := strings.TrimSuffix(*cfg.VaultAddress, "/")
+	if address == "" {
+		return nil, fmt.Errorf("vault is not configured, set --vault-address with acorn install")
+	}
+
+	path, err := scopedPath(*cfg.VaultPathPrefix, namespace, secretPath)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := login(ctx, c, address, *cfg.VaultKubernetesRole)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := do(ctx, http.MethodGet, address+"/v1/"+path, token, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s from vault: %w", path, err)
+	}
+	if resp.Data.Data == nil {
+		return nil, fmt.Errorf("reading %s from vault: no data found, the path of a KV version 2 secret must include /data/", path)
+	}
+
+	data := make(map[string][]byte, len(resp.Data.Data))
+	for k, v := range resp.Data.Data {
+		if s, ok := v.(string); ok {
+			data[k] = []byte(s)
+			continue
+		}
+		// Values that are not strings are stored as JSON
+		data[k], err = json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// scopedPath returns the cleaned path of a secret if it is under the path prefix of the project in namespace
+func scopedPath(prefix, namespace, secretPath string) (string, error) {
+	prefix = strings.Trim(path.Clean("/"+strings.ReplaceAll(prefix, namespaceVariable, namespace)), "/")
+	cleaned := strings.TrimPrefix(path.Clean("/"+secretPath), "/")
+	if cleaned != prefix && !strings.HasPrefix(cleaned, prefix+"/") {
+		return "", fmt.Errorf("path %s is not allowed, the external secrets of project %s must be under %s", secretPath,
+			namespace, prefix)
+	}
+	return cleaned, nil
+}
+
+// login returns a Vault token. With a role set the controller logs in using the Kubernetes auth method, otherwise the
+// token key of the acorn-vault secret in acorn-system is used.
+func login(ctx context.Context, c kclient.Reader, address, role string) (string, error) {
+	if role == "" {
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, router.Key(system.Namespace, system.VaultSecretName), secret); apierrors.IsNotFound(err) {
+			return "", fmt.Errorf("vault token is not configured, create the %s secret in %s or set --vault-kubernetes-role with acorn install",
+				system.VaultSecretName, system.Namespace)
+		} else if err != nil {
+			return "", err
+		}
+		if len(secret.Data["token"]) == 0 {
+			return "", fmt.Errorf("secret %s/%s is missing the token key", system.Namespace, system.VaultSecretName)
+		}
+		return string(secret.Data["token"]), nil
+	}
+
+	jwt, err := os.ReadFile(ServiceAccountTokenFile)
+	if err != nil {
+		return "", fmt.Errorf("reading service account token to log in to vault: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"jwt":  strings.TrimSpace(string(jwt)),
+		"role": role,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := do(ctx, http.MethodPost, address+"/v1/auth/kubernetes/login", "", body)
+	if err != nil {
+		return "", fmt.Errorf("logging in to vault as role %s: %w", role, err)
+	}
+	if resp.Auth.ClientToken == "" {
+		return "", fmt.Errorf("logging in to vault as role %s: no token returned", role)
+	}
+	return resp.Auth.ClientToken, nil
+}
+
+func do(ctx context.Context, method, url, token string, body []byte) (*response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, result); err != nil && resp.StatusCode == http.StatusOK {
+			return nil, err
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if len(result.Errors) > 0 {
+			return nil, fmt.Errorf("%s: %s", resp.Status, strings.Join(result.Errors, ", "))
+		}
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	return result, nil
+}
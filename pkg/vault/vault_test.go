@@ -0,0 +1,156 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apiv1 "github.com/acorn-io/acorn/pkg/apis/api.acorn.io/v1"
+	"github.com/acorn-io/acorn/pkg/config"
+	"github.com/acorn-io/acorn/pkg/scheme"
+	"github.com/acorn-io/acorn/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// server responds like a Vault dev server with the secret acorn/app-ns/db in the KV version 2 engine mounted at secret
+func server(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/kubernetes/login", func(rw http.ResponseWriter, req *http.Request) {
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		if body["jwt"] != "sa-token" || body["role"] != "acorn" {
+			rw.WriteHeader(http.StatusBadRequest)
+			_, _ = rw.Write([]byte(`{"errors":["invalid role name \"` + body["role"] + `\""]}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"auth":{"client_token":"k8s-token","lease_duration":3600}}`))
+	})
+	mux.HandleFunc("/v1/secret/data/acorn/app-ns/db", func(rw http.ResponseWriter, req *http.Request) {
+		if token := req.Header.Get("X-Vault-Token"); token != "root" && token != "k8s-token" {
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = rw.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{
+  "request_id": "2c3a7f3e-8a8a-9a54-7c2c-1c5d0e4e6d8b",
+  "data": {
+    "data": {"username": "admin", "password": "s3cret", "port": 5432},
+    "metadata": {"created_time": "2022-12-19T12:00:00.000000Z", "version": 2}
+  }
+}`))
+	})
+	mux.HandleFunc("/v1/secret/data/acorn/app-ns/missing", func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"errors":[]}`))
+	})
+
+	s := httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func client(t *testing.T, cfg *apiv1.Config, objs ...kclient.Object) kclient.Client {
+	t.Helper()
+
+	cm, err := config.AsConfigMap(cfg)
+	require.NoError(t, err)
+	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(append(objs, cm)...).Build()
+}
+
+func TestReadWithToken(t *testing.T) {
+	s := server(t)
+	c := client(t, &apiv1.Config{VaultAddress: &s.URL}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      system.VaultSecretName,
+			Namespace: system.Namespace,
+		},
+		Data: map[string][]byte{
+			"token": []byte("root"),
+		},
+	})
+
+	data, err := Read(context.Background(), c, "app-ns", "secret/data/acorn/app-ns/db")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("s3cret"),
+		"port":     []byte("5432"),
+	}, data)
+
+	_, err = Read(context.Background(), c, "app-ns", "secret/data/acorn/app-ns/missing")
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestReadWithKubernetesAuth(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("sa-token\n"), 0600))
+	defer func(file string) { ServiceAccountTokenFile = file }(ServiceAccountTokenFile)
+	ServiceAccountTokenFile = tokenFile
+
+	s := server(t)
+	role := "acorn"
+	data, err := Read(context.Background(), client(t, &apiv1.Config{VaultAddress: &s.URL, VaultKubernetesRole: &role}), "app-ns", "secret/data/acorn/app-ns/db")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", string(data["password"]))
+
+	role = "other"
+	_, err = Read(context.Background(), client(t, &apiv1.Config{VaultAddress: &s.URL, VaultKubernetesRole: &role}), "app-ns", "secret/data/acorn/app-ns/db")
+	assert.ErrorContains(t, err, `invalid role name "other"`)
+}
+
+func TestReadNotConfigured(t *testing.T) {
+	_, err := Read(context.Background(), client(t, &apiv1.Config{}), "app-ns", "secret/data/acorn/app-ns/db")
+	assert.ErrorContains(t, err, "vault is not configured")
+
+	s := server(t)
+	_, err = Read(context.Background(), client(t, &apiv1.Config{VaultAddress: &s.URL}), "app-ns", "secret/data/acorn/app-ns/db")
+	assert.ErrorContains(t, err, "vault token is not configured")
+}
+
+func TestReadOutsideScope(t *testing.T) {
+	s := server(t)
+	c := client(t, &apiv1.Config{VaultAddress: &s.URL}, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      system.VaultSecretName,
+			Namespace: system.Namespace,
+		},
+		Data: map[string][]byte{
+			"token": []byte("root"),
+		},
+	})
+
+	for _, path := range []string{
+		"secret/data/acorn/app-ns/db",
+		"/secret/data/acorn/app-ns/./db",
+	} {
+		_, err := Read(context.Background(), c, "app-ns", path)
+		assert.NoError(t, err, path)
+	}
+
+	for _, path := range []string{
+		"secret/data/db",
+		"secret/data/acorn/other-ns/db",
+		"secret/data/acorn/app-ns-other/db",
+		"secret/data/acorn/app-ns/../other-ns/db",
+		"secret/data/acorn",
+	} {
+		_, err := Read(context.Background(), c, "app-ns", path)
+		assert.ErrorContains(t, err, "is not allowed, the external secrets of project app-ns must be under secret/data/acorn/app-ns", path)
+	}
+
+	prefix := "kv/data/{{.Namespace}}/"
+	c = client(t, &apiv1.Config{VaultAddress: &s.URL, VaultPathPrefix: &prefix})
+	_, err := Read(context.Background(), c, "app-ns", "secret/data/acorn/app-ns/db")
+	assert.ErrorContains(t, err, "must be under kv/data/app-ns")
+}
@@ -19,14 +19,50 @@ package v1
 	labels:                       [string]: string
 	annotations:                  [string]: string
 	scale?: >=0
+	autoscale?: #Autoscale
+	stateful?: bool
+	rollout?: #Rollout
+	disruption?: #Disruption
+	#Placement
+	spread?: "zone" | "node"
 	sidecars: [string]: #Sidecar
 }
 
+#Rollout: {
+	maxSurge?:        #IntOrPercent
+	maxUnavailable?:  #IntOrPercent
+	minReadySeconds?: int & >=0
+}
+
+#Disruption: {minAvailable: #IntOrPercent} | {maxUnavailable: #IntOrPercent}
+
+#IntOrPercent: int & >=0 | =~"^[0-9]+%$"
+
+#Placement: {
+	nodeSelector?: [string]: string
+	tolerations?: [...#Toleration]
+}
+
+#Toleration: {
+	key?:      string
+	operator?: "Equal" | "Exists"
+	value?:    string
+	effect?:   "NoSchedule" | "PreferNoSchedule" | "NoExecute"
+}
+
+#Autoscale: {
+	min:           int & >=1 | *1
+	max:           int & >=min
+	targetCPU?:    int & >0
+	targetMemory?: int & >0
+}
+
 #Job: {
 	#ContainerBase
 	labels:                       [string]: string
 	annotations:                  [string]: string
 	schedule: string | *""
+	#Placement
 	sidecars: [string]: #Sidecar
 }
 
@@ -92,12 +128,37 @@ package v1
 	ports:                          #PortSingle | *[...#Port] | #PortMap
 	[=~"probes|probe"]:             #Probes
 	[=~"depends[oO]n|depends_on"]:  string | *[...string]
+	memory?:                        #ComputeResource
+	cpu?:                           #ComputeResource
+	securityContext?:               #SecurityContext
 	permissions: {
 		rules: [...#RuleSpec]
 		clusterRules: [...#RuleSpec]
 	}
 }
 
+// A single quantity (ex: "512Mi", "250m", 2) sets both the request and the limit
+#ComputeResource: #Quantity | {
+	request?: #Quantity
+	limit?:   #Quantity
+}
+
+#Quantity: string | number
+
+#SecurityContext: {
+	runAsUser?:                int & >=0
+	runAsGroup?:               int & >=0
+	runAsNonRoot?:             bool
+	readOnlyRootFilesystem?:   bool
+	privileged?:               bool
+	allowPrivilegeEscalation?: bool
+	capabilities?: {
+		add?: [...string]
+		drop?: [...string]
+	}
+	seccompProfile?: "RuntimeDefault" | "Unconfined"
+}
+
 #ShortVolumeRef: "^[a-z][-a-z0-9]*$"
 #VolumeRef:      "^volume://.+$"
 #EphemeralRef:   "^ephemeral://.*$|^$"
@@ -105,8 +166,19 @@ package v1
 #SecretRef:      "^secret://[a-z][-a-z0-9]*(.onchange=(redeploy|no-action))?$"
 
 // The below should work but doesn't. So instead we use the log regexp. This seems like a cue bug
-// #Dir: #ShortVolumeRef | #VolumeRef | #EphemeralRef | #ContextDirRef | #SecretRef
-#Dir: =~"^[a-z][-a-z0-9]*$|^volume://.+$|^ephemeral://.*$|^$|^\\./.*$|^secret://[a-z][-a-z0-9]*(.onchange=(redeploy|no-action))?$"
+// #DirRef: #ShortVolumeRef | #VolumeRef | #EphemeralRef | #ContextDirRef | #SecretRef
+#DirRef: =~"^[a-z][-a-z0-9]*$|^volume://.+$|^ephemeral://.*$|^$|^\\./.*$|^secret://[a-z][-a-z0-9]*(.onchange=(redeploy|no-action))?$"
+
+#Dir: #DirRef | #DirSpec
+
+#DirSpec: {
+	volume:   =~"^[a-z][-a-z0-9]*$|^volume://.+$|^ephemeral://.*$"
+	subPath?: string
+	// A context dir or an image of the app (ex: "images.fixtures") whose content at the mount path is copied into
+	// the volume the first time it is mounted
+	seedFrom?:      =~#ContextDirRef
+	seedFromImage?: =~"^images\\.[a-z][-a-z0-9]*$"
+}
 
 #PortSingle: (>0 & <65536) | =~#PortRegexp
 #Port:       (>0 & <65536) | =~#PortRegexp | #PortSpec
@@ -171,6 +243,11 @@ package v1
 #SecretTemplate: {
 	#SecretBase
 	type: "template"
+	params: {
+		// How the values are rendered. "substitution" replaces ${secret://} and ${image://} references, "go" renders
+		// the values as Go templates
+		engine: *"substitution" | "go"
+	}
 	data: [string]: string
 }
 
@@ -178,6 +255,7 @@ package v1
 	#SecretBase
 	type: "token"
 	params: {
+		#SecretRotation
 		// The character set used in the generated string
 		characters: string | *"bcdfghjklmnpqrstvwxz2456789"
 		// The length of the token to be generated
@@ -188,9 +266,17 @@ package v1
 	}
 }
 
+#SecretRotation: {
+	// How often the generated value is regenerated, as a duration like "720h" or a cron expression
+	rotate?: string
+	// How long the value from before a rotation is kept under the previous- key, defaults to 24h
+	rotateGracePeriod?: string
+}
+
 #SecretBasicAuth: {
 	#SecretBase
 	type: "basic"
+	params?: #SecretRotation
 	data: {
 		username?: string
 		password?: string
@@ -207,7 +293,65 @@ package v1
 	data: {}
 }
 
-#Secret: *#SecretOpaque | #SecretBasicAuth | #SecretGenerated | #SecretTemplate | #SecretToken
+#SecretTLS: {
+	#SecretBase
+	type: "tls"
+	params: {
+		// The common name of the certificate, defaults to the first SAN
+		commonName?: string
+		// The DNS names and IP addresses of the certificate, defaults to the names of the containers
+		sans: [...string]
+		// The algorithm of the private key
+		algorithm: *"ecdsa" | "rsa" | "ed25519"
+		// How long the certificate is valid for. It is re-issued after two thirds of this time
+		validity: string | *"2160h"
+		// Whether the certificate is signed by a CA of the app or one shared by all apps in the project
+		ca: *"app" | "project"
+	}
+	data: {}
+}
+
+#SecretSSHKey: {
+	#SecretBase
+	type: "ssh-key"
+	params: {
+		// The algorithm of the generated key
+		algorithm: *"ed25519" | "ecdsa" | "rsa"
+	}
+	data: {
+		"ssh-privatekey"?: string
+		"ssh-publickey"?:  string
+	}
+}
+
+#SecretKeyPair: {
+	#SecretBase
+	type: "keypair"
+	params: {
+		// The algorithm of the generated key
+		algorithm: *"ecdsa" | "ed25519" | "rsa"
+	}
+	data: {
+		"private.pem"?: string
+		"public.pem"?:  string
+	}
+}
+
+#SecretExternal: {
+	#SecretBase
+	type: "external"
+	params: {
+		// The store the values are read from
+		provider: "vault"
+		// The API path of the secret in a KV version 2 engine, such as "kv/data/db"
+		path: string & !=""
+		// How often the values are read again
+		refresh: string | *"1h"
+	}
+	data: {}
+}
+
+#Secret: *#SecretOpaque | #SecretBasicAuth | #SecretGenerated | #SecretTemplate | #SecretToken | #SecretTLS | #SecretSSHKey | #SecretKeyPair | #SecretExternal
 
 #Router: {
 	labels: [string]:      string
@@ -221,9 +365,35 @@ package v1
 }
 
 #RouteTarget: {
-	pathType:          "exact" | *"prefix"
+	pathType: "exact" | *"prefix"
+	{
+		targetServiceName: =~#DNSName
+		targetPort?:       int
+		#RouteProxy
+	} | {
+		targets: [...#WeightedRouteTarget]
+		#RouteProxy
+	} | {
+		redirect: #RouteRedirect
+	}
+}
+
+#RouteProxy: {
+	rewrite?: =~#PathName
+	setRequestHeaders?: [=~#HeaderName]: string
+	removeResponseHeaders?: [...=~#HeaderName]
+}
+
+#RouteRedirect: (string & !="") | {
+	url:        string & !=""
+	statusCode: *302 | 301 | 303 | 307 | 308
+}
+
+#WeightedRouteTarget: {
 	targetServiceName: =~#DNSName
 	targetPort?:       int
+	weight:            *1 | int & >=0
+	headers: [string]: string
 }
 
 #RouteMap: [=~#PathName]: {
@@ -234,6 +404,8 @@ package v1
 
 #PathName: "/.*"
 
+#HeaderName: "^[A-Za-z0-9-]+$"
+
 #DNSName: "[a-z][-a-z0-9]*"
 
 #Args: string | int | float | bool | [...string] | {...}